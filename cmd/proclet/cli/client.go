@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
@@ -12,30 +15,70 @@ import (
 )
 
 var addr string
+var clientLanguageID string
+var clientProcessorID string
+var clientTaskID string
+var clientArchive string
+var clientArtifactDir string
 
 func init() {
 	clientCmd.Flags().StringVarP(&addr, "addr", "a", "http://localhost:9000", "server address")
+	clientCmd.Flags().StringVarP(&clientLanguageID, "language", "l", "", "language ID or alias. detected from files if empty")
+	clientCmd.Flags().StringVarP(&clientProcessorID, "processor", "p", "", "processor ID. the default of the language if empty")
+	clientCmd.Flags().StringVarP(&clientTaskID, "task", "t", "", "task ID. the default of the processor if empty")
+	clientCmd.Flags().StringVar(&clientArchive, "archive", "", "tar, tar.gz or zip archive extracted in addition to files")
+	clientCmd.Flags().StringVar(&clientArtifactDir, "artifactDir", "", "directory to save artifacts. not saved if empty")
 
 	rootCmd.AddCommand(clientCmd)
 }
 
 var clientCmd = &cobra.Command{
-	Use: "client",
+	Use: "client [files...]",
 	Run: func(cmd *cobra.Command, args []string) {
 		c := apiv1connect.NewRunnerServiceClient(http.DefaultClient, addr, connect.WithGRPC())
 
+		files := []*apiv1pb.File{
+			{
+				Path:    "main.sh",
+				Content: []byte("ulimit -a; uname -a; whoami; sleep 5; echo hello"),
+			},
+		}
+		var archive *apiv1pb.Archive
+		if clientArchive != "" {
+			content, err := os.ReadFile(clientArchive)
+			if err != nil {
+				log.Panicf("failed to read archive: %s", err)
+			}
+			archive = &apiv1pb.Archive{Content: content}
+			files = nil
+		}
+		if len(args) > 0 {
+			files = nil
+			for _, path := range args {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					log.Panicf("failed to read file: %s", err)
+				}
+				info, err := os.Stat(path)
+				if err != nil {
+					log.Panicf("failed to stat file: %s", err)
+				}
+				files = append(files, &apiv1pb.File{
+					Path:       filepath.Base(path),
+					Content:    content,
+					Executable: info.Mode()&0111 != 0,
+				})
+			}
+		}
+
 		ctx := cmd.Context()
 		stream, err := c.RunOneshot(ctx, connect.NewRequest(&apiv1pb.RunOneshotRequest{
-			LanguageId:  "test-lang",
-			ProcessorId: "a-latest",
-			TaskId:      "run",
-
-			Files: []*apiv1pb.File{
-				{
-					Path:    "main.sh",
-					Content: []byte("ulimit -a; uname -a; whoami; sleep 5; echo hello"),
-				},
-			},
+			LanguageId:  clientLanguageID,
+			ProcessorId: clientProcessorID,
+			TaskId:      clientTaskID,
+			Archive:     archive,
+
+			Files: files,
 		}))
 		if err != nil {
 			log.Panicf("failed to run: %s", err)
@@ -47,6 +90,23 @@ var clientCmd = &cobra.Command{
 			case *apiv1pb.RunOneshotResponse_Output:
 				// res.Output.Kind
 				log.Printf("output: %s", res.Output.Buffer)
+			case *apiv1pb.RunOneshotResponse_Resolution:
+				log.Printf("resolved: %+v", res.Resolution)
+			case *apiv1pb.RunOneshotResponse_Warning:
+				log.Printf("warning: %s", res.Warning.Message)
+			case *apiv1pb.RunOneshotResponse_Result:
+				log.Printf("result(%s): %+v", stream.Msg().Phase, res.Result)
+			case *apiv1pb.RunOneshotResponse_Artifact:
+				if res.Artifact.OmittedReason != "" {
+					log.Printf("artifact: %s (%d bytes) omitted: %s", res.Artifact.Path, res.Artifact.Size, res.Artifact.OmittedReason)
+					continue
+				}
+				log.Printf("artifact: %s (%d bytes)", res.Artifact.Path, res.Artifact.Size)
+				if clientArtifactDir != "" {
+					if err := saveArtifact(ctx, c, clientArtifactDir, res.Artifact); err != nil {
+						log.Panicf("failed to save artifact: %s", err)
+					}
+				}
 			}
 		}
 		if err := stream.Err(); err != nil {
@@ -54,3 +114,32 @@ var clientCmd = &cobra.Command{
 		}
 	},
 }
+
+// saveArtifact writes the artifact under the directory. Large ones are downloaded by handles.
+func saveArtifact(ctx context.Context, c apiv1connect.RunnerServiceClient, dir string, artifact *apiv1pb.Artifact) error {
+	path := filepath.Join(dir, filepath.FromSlash(filepath.Clean("/"+artifact.Path)))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if artifact.Handle == "" {
+		_, err := f.Write(artifact.Content)
+		return err
+	}
+
+	stream, err := c.DownloadArtifact(ctx, connect.NewRequest(&apiv1pb.DownloadArtifactRequest{Handle: artifact.Handle}))
+	if err != nil {
+		return err
+	}
+	for stream.Receive() {
+		if _, err := f.Write(stream.Msg().Chunk); err != nil {
+			return err
+		}
+	}
+	return stream.Err()
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yutopp/proclet/pkg/server"
+	"github.com/yutopp/proclet/pkg/service/container"
+)
+
+var imagesCmd = &cobra.Command{
+	Use: "images",
+}
+
+var imagesPullCmd = &cobra.Command{
+	Use: "pull",
+	Run: func(cmd *cobra.Command, args []string) {
+		profileRepo := server.NewProfileFromFile(profilePath)
+		profile, err := profileRepo.LoadExpanded()
+		if err != nil {
+			log.Panicf("failed to load profile: %s", err)
+		}
+
+		images := container.NewDockerImages()
+
+		failed := false
+		for _, image := range profile.DockerImages() {
+			if err := images.Pull(cmd.Context(), image); err != nil {
+				log.Printf("failed to pull: %s", err)
+				failed = true
+				continue
+			}
+			log.Printf("pulled: %s", image)
+		}
+		if failed {
+			log.Panicf("failed to pull some images")
+		}
+	},
+}
+
+func init() {
+	imagesCmd.AddCommand(imagesPullCmd)
+
+	rootCmd.AddCommand(imagesCmd)
+}
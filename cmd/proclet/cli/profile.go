@@ -1,12 +1,16 @@
 package cli
 
 import (
+	"fmt"
 	"log"
+	"os"
 
+	"github.com/cockroachdb/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/yutopp/proclet/pkg/domain"
 	"github.com/yutopp/proclet/pkg/server"
+	"github.com/yutopp/proclet/pkg/service/container"
 )
 
 var profileCmd = &cobra.Command{
@@ -22,7 +26,182 @@ var profileCmd = &cobra.Command{
 	},
 }
 
+var profileLockPull bool
+
+// profileLockCmd resolves image tags of processors to digests, and pins them in the profile.
+var profileLockCmd = &cobra.Command{
+	Use: "lock",
+	Run: func(cmd *cobra.Command, args []string) {
+		profileRepo := server.NewProfileFromFile(profilePath)
+		profile, err := profileRepo.Load()
+		if err != nil {
+			log.Panicf("failed to load profile: %s", err)
+		}
+		if profile == nil {
+			profile = &domain.Profile{}
+		}
+		// Images may be inherited from templates
+		expanded, err := profile.Expand()
+		if err != nil {
+			log.Panicf("failed to expand profile: %s", err)
+		}
+
+		images := container.NewDockerImages()
+		for i := range expanded.Languages {
+			lang := &expanded.Languages[i]
+			for j := range lang.Processors {
+				proc := &lang.Processors[j]
+
+				// Resolve by the tag even if already pinned to follow updates of the tag
+				if profileLockPull {
+					err = images.Pull(cmd.Context(), proc.DockerImage)
+				} else {
+					err = images.Ensure(cmd.Context(), proc.DockerImage, false)
+				}
+				if err != nil {
+					log.Panicf("failed to prepare image: %s", err)
+				}
+				digest, err := images.Digest(cmd.Context(), proc.DockerImage)
+				if err != nil {
+					log.Panicf("failed to resolve digest: %s", err)
+				}
+
+				log.Printf("locked: %s/%s: %s@%s", lang.ID, proc.ID, proc.DockerImage, digest)
+				profile.Languages[i].Processors[j].DockerImageDigest = digest
+			}
+		}
+
+		if err := profileRepo.Save(profile); err != nil {
+			log.Panicf("failed to save profile: %s", err)
+		}
+	},
+}
+
+var profileValidateCheckImages bool
+
+// profileValidateCmd reports every problem of the profile with JSON paths.
+var profileValidateCmd = &cobra.Command{
+	Use: "validate",
+	Run: func(cmd *cobra.Command, args []string) {
+		profileRepo := server.NewProfileFromFile(profilePath)
+		profile, err := profileRepo.Load()
+		if err != nil {
+			log.Panicf("failed to load profile: %s", err)
+		}
+
+		var problems domain.ValidationErrors
+		expanded, err := server.ExpandProfile(profile)
+		if err != nil {
+			problems = append(problems, validationProblems(err)...)
+		}
+
+		if profileValidateCheckImages && expanded != nil {
+			images := container.NewDockerImages()
+			for i, lang := range expanded.Languages {
+				for j, proc := range lang.Processors {
+					if proc.DockerImage == "" {
+						continue // already reported
+					}
+					if err := images.Ensure(cmd.Context(), proc.ImageRef(), false); err != nil {
+						problems = append(problems, &domain.ValidationError{
+							Path:    fmt.Sprintf("$.languages[%d].processors[%d].docker_image", i, j),
+							Message: err.Error(),
+						})
+					}
+				}
+			}
+		}
+
+		for _, p := range problems {
+			fmt.Println(p.Error())
+		}
+		if len(problems) > 0 {
+			log.Fatalf("%d problems found in %s", len(problems), profilePath)
+		}
+		log.Printf("valid: %s", profilePath)
+	},
+}
+
+// validationProblems returns problems in the error, or the error itself as a problem of the whole profile.
+func validationProblems(err error) domain.ValidationErrors {
+	var problems domain.ValidationErrors
+	if errors.As(err, &problems) {
+		return problems
+	}
+	return domain.ValidationErrors{{Path: "$", Message: err.Error()}}
+}
+
+var profileVersionsPull bool
+
+// profileVersionsCmd runs version commands of processors in their images, and prints detected versions.
+var profileVersionsCmd = &cobra.Command{
+	Use: "versions",
+	Run: func(cmd *cobra.Command, args []string) {
+		profileRepo := server.NewProfileFromFile(profilePath)
+		profile, err := profileRepo.LoadExpanded()
+		if err != nil {
+			log.Panicf("failed to load profile: %s", err)
+		}
+
+		images := container.NewDockerImages()
+		detector := server.NewVersionDetector("", uid, gid)
+
+		failed := false
+		for _, lang := range profile.Languages {
+			for _, proc := range lang.Processors {
+				if len(proc.VersionCmd) == 0 {
+					fmt.Printf("%s/%s: %s (not detected)\n", lang.ID, proc.ID, proc.Version)
+					continue
+				}
+
+				image := proc.ImageRef()
+				if err := images.Ensure(cmd.Context(), image, profileVersionsPull); err != nil {
+					log.Printf("failed to prepare image: %s", err)
+					failed = true
+					continue
+				}
+				digest, err := images.Digest(cmd.Context(), image)
+				if err != nil {
+					log.Printf("failed to resolve digest: %s", err)
+				}
+
+				version, err := detector.Detect(cmd.Context(), image, digest, proc.VersionCmd)
+				if err != nil {
+					log.Printf("failed to detect version: %s/%s: %s", lang.ID, proc.ID, err)
+					failed = true
+					continue
+				}
+				fmt.Printf("%s/%s: %s\n", lang.ID, proc.ID, version)
+			}
+		}
+		if failed {
+			log.Fatalf("failed to detect some versions")
+		}
+	},
+}
+
+// profileSchemaCmd prints the JSON Schema of profiles.
+var profileSchemaCmd = &cobra.Command{
+	Use: "schema",
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Stdout.Write(domain.ProfileJSONSchema)
+	},
+}
+
 func init() {
+	profileValidateCmd.Flags().BoolVar(&profileValidateCheckImages, "checkImages", false, "check that images exist locally")
+	profileCmd.AddCommand(profileValidateCmd)
+
+	profileCmd.AddCommand(profileSchemaCmd)
+
+	profileVersionsCmd.Flags().BoolVar(&profileVersionsPull, "pull", false, "pull missing images")
+	profileVersionsCmd.Flags().IntVar(&uid, "uid", 0, "runner uid")
+	profileVersionsCmd.Flags().IntVar(&gid, "gid", 0, "runner gid")
+	profileCmd.AddCommand(profileVersionsCmd)
+
+	profileLockCmd.Flags().BoolVar(&profileLockPull, "pull", true, "pull the latest images of tags before resolving")
+	profileCmd.AddCommand(profileLockCmd)
+
 	rootCmd.AddCommand(profileCmd)
 }
 
@@ -48,7 +227,7 @@ var L = []domain.Language{
 
 						Compile: nil,
 						Run: &domain.PhasedTask{
-							Cmd: []string{"sh", "main.sh"},
+							Cmd: []string{"sh", "{source}"},
 						},
 					},
 				},
@@ -0,0 +1,440 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	"github.com/yutopp/proclet/pkg/server"
+)
+
+var profileSets []string
+var profileUnsets []string
+
+// profileListCmd prints languages, processors and tasks as written in the profile.
+var profileListCmd = &cobra.Command{
+	Use: "list",
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := server.NewProfileFromFile(profilePath).Load()
+		if err != nil {
+			log.Panicf("failed to load profile: %s", err)
+		}
+		if profile == nil {
+			profile = &domain.Profile{}
+		}
+
+		for _, lang := range profile.Languages {
+			fmt.Printf("%s (%s)\n", lang.ID, lang.ShowName)
+			for _, proc := range lang.Processors {
+				printProcessor("  ", &proc)
+			}
+		}
+		if len(profile.Templates) > 0 {
+			fmt.Println("templates:")
+			for _, proc := range profile.Templates {
+				printProcessor("  ", &proc)
+			}
+		}
+	},
+}
+
+func printProcessor(indent string, proc *domain.Processor) {
+	fmt.Printf("%s%s (%s) %s", indent, proc.ID, proc.ShowName, proc.ImageRef())
+	if proc.Extends != "" {
+		fmt.Printf(" extends %s", proc.Extends)
+	}
+	if proc.Status != "" {
+		fmt.Printf(" (%s)", proc.Status)
+	}
+	fmt.Println()
+	for _, task := range proc.Tasks {
+		fmt.Printf("%s  %s [%s] %s", indent, task.ID, task.Kind, task.ShowName)
+		if task.Status != "" {
+			fmt.Printf(" (%s)", task.Status)
+		}
+		fmt.Println()
+	}
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a language, a processor or a task. Fields are given by --set key=value",
+}
+
+var profileAddLanguageProcessorFiles []string
+
+// profileAddLanguageCmd adds a language with processors imported from files since languages must have processors.
+var profileAddLanguageCmd = &cobra.Command{
+	Use:  "language <language> --import <processor file>...",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		lang := domain.Language{ID: args[0]}
+		for _, path := range profileAddLanguageProcessorFiles {
+			proc, err := server.LoadProcessorFile(path)
+			if err != nil {
+				log.Panicf("failed to load processor: %s", err)
+			}
+			if err := lang.AddProcessor(*proc); err != nil {
+				log.Fatalf("failed to add processor: %s", err)
+			}
+		}
+
+		editProfile(func(profile *domain.Profile) error {
+			if err := setFields(&lang, "processors"); err != nil {
+				return err
+			}
+			return profile.AddLanguage(lang)
+		})
+	},
+}
+
+var profileAddProcessorCmd = &cobra.Command{
+	Use:  "processor <language> <processor>",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			lang, err := profile.Language(args[0])
+			if err != nil {
+				return err
+			}
+			proc := domain.Processor{ID: args[1]}
+			if err := setFields(&proc, "tasks"); err != nil {
+				return err
+			}
+			return lang.AddProcessor(proc)
+		})
+	},
+}
+
+var profileAddTaskCmd = &cobra.Command{
+	Use:  "task <language> <processor> <task>",
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			proc, err := findProcessor(profile, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			task := domain.Task{ID: args[2]}
+			if err := setFields(&task, ""); err != nil {
+				return err
+			}
+			return proc.AddTask(task)
+		})
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use: "remove",
+}
+
+var profileRemoveLanguageCmd = &cobra.Command{
+	Use:  "language <language>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			return profile.RemoveLanguage(args[0])
+		})
+	},
+}
+
+var profileRemoveProcessorCmd = &cobra.Command{
+	Use:  "processor <language> <processor>",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			lang, err := profile.Language(args[0])
+			if err != nil {
+				return err
+			}
+			return lang.RemoveProcessor(args[1])
+		})
+	},
+}
+
+var profileRemoveTaskCmd = &cobra.Command{
+	Use:  "task <language> <processor> <task>",
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			proc, err := findProcessor(profile, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return proc.RemoveTask(args[2])
+		})
+	},
+}
+
+var profileEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit fields of a language, a processor or a task by --set key=value and --unset key",
+}
+
+var profileEditLanguageCmd = &cobra.Command{
+	Use:  "language <language>",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			lang, err := profile.Language(args[0])
+			if err != nil {
+				return err
+			}
+			return setFields(lang, "processors")
+		})
+	},
+}
+
+var profileEditProcessorCmd = &cobra.Command{
+	Use:  "processor <language> <processor>",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			proc, err := findProcessor(profile, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return setFields(proc, "tasks")
+		})
+	},
+}
+
+var profileEditTaskCmd = &cobra.Command{
+	Use:  "task <language> <processor> <task>",
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		editProfile(func(profile *domain.Profile) error {
+			proc, err := findProcessor(profile, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			task, err := proc.Task(args[2])
+			if err != nil {
+				return err
+			}
+			return setFields(task, "")
+		})
+	},
+}
+
+var profileImportReplace bool
+
+// profileImportCmd adds a processor defined in a JSON or YAML file to the language.
+var profileImportCmd = &cobra.Command{
+	Use:  "import <language> <file>",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		proc, err := server.LoadProcessorFile(args[1])
+		if err != nil {
+			log.Panicf("failed to load processor: %s", err)
+		}
+
+		editProfile(func(profile *domain.Profile) error {
+			lang, err := profile.Language(args[0])
+			if err != nil {
+				return err
+			}
+			if profileImportReplace {
+				if existing, err := lang.Processor(proc.ID); err == nil {
+					*existing = *proc
+					return nil
+				}
+			}
+			return lang.AddProcessor(*proc)
+		})
+	},
+}
+
+var profileDiffRaw bool
+
+// profileDiffCmd prints changes from the first profile to the second one.
+var profileDiffCmd = &cobra.Command{
+	Use:  "diff <from> <to>",
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		load := func(path string) *domain.Profile {
+			repo := server.NewProfileFromFile(path)
+			var profile *domain.Profile
+			var err error
+			if profileDiffRaw {
+				profile, err = repo.Load()
+			} else {
+				profile, err = repo.LoadExpanded()
+			}
+			if err != nil {
+				log.Panicf("failed to load profile: %s: %s", path, err)
+			}
+			if profile == nil {
+				profile = &domain.Profile{}
+			}
+			return profile
+		}
+
+		changes, err := domain.Diff(load(args[0]), load(args[1]))
+		if err != nil {
+			log.Panicf("failed to diff profiles: %s", err)
+		}
+		for _, c := range changes {
+			fmt.Println(c.String())
+		}
+		if len(changes) == 0 {
+			log.Printf("no differences")
+		}
+	},
+}
+
+// editProfile applies the edit to the profile as written, and saves it only if the result is valid.
+func editProfile(edit func(profile *domain.Profile) error) {
+	profileRepo := server.NewProfileFromFile(profilePath)
+	profile, err := profileRepo.Load()
+	if err != nil {
+		log.Panicf("failed to load profile: %s", err)
+	}
+	if profile == nil {
+		profile = &domain.Profile{}
+	}
+
+	if err := edit(profile); err != nil {
+		log.Fatalf("failed to edit profile: %s", err)
+	}
+
+	expanded, err := profile.Expand()
+	if err == nil {
+		err = expanded.Validate()
+	}
+	if err != nil {
+		var problems domain.ValidationErrors
+		if errors.As(err, &problems) {
+			for _, p := range problems {
+				fmt.Println(p.Error())
+			}
+		}
+		log.Fatalf("profile is not saved since it becomes invalid: %s", err)
+	}
+
+	if err := profileRepo.Save(profile); err != nil {
+		log.Panicf("failed to save profile: %s", err)
+	}
+	log.Printf("saved: %s", profilePath)
+}
+
+func findProcessor(profile *domain.Profile, languageID, processorID string) (*domain.Processor, error) {
+	lang, err := profile.Language(languageID)
+	if err != nil {
+		return nil, err
+	}
+	return lang.Processor(processorID)
+}
+
+// setFields applies --unset and --set to the target. Keys are field names in the profile.
+// Values are parsed as YAML, e.g. `--set cmd='[gcc, "{source}"]'`, or taken as strings if not parsable.
+// The nested field is edited by its own subcommands.
+func setFields(target interface{}, nested string) error {
+	if len(profileUnsets) > 0 {
+		var fields map[string]json.RawMessage
+		b, err := json.Marshal(target)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &fields); err != nil {
+			return err
+		}
+		for _, key := range profileUnsets {
+			if key == "id" || key == nested {
+				return errors.Newf("field cannot be unset: '%s'", key)
+			}
+			if _, ok := fields[key]; !ok {
+				return errors.Newf("unknown or unset field: '%s'", key)
+			}
+			delete(fields, key)
+		}
+		if b, err = json.Marshal(fields); err != nil {
+			return err
+		}
+		if err := resetJSON(target, b); err != nil {
+			return err
+		}
+	}
+
+	if len(profileSets) == 0 {
+		return nil
+	}
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, set := range profileSets {
+		key, value, ok := strings.Cut(set, "=")
+		if !ok {
+			return errors.Newf("must be key=value: '%s'", set)
+		}
+		if key == nested {
+			return errors.Newf("field cannot be set, use subcommands instead: '%s'", key)
+		}
+
+		var valueNode yaml.Node
+		if err := yaml.Unmarshal([]byte(value), &valueNode); err != nil || len(valueNode.Content) == 0 {
+			valueNode = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+		} else {
+			valueNode = *valueNode.Content[0] // unwrap the document
+		}
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &valueNode)
+	}
+
+	var buf bytes.Buffer
+	if err := yaml.NewEncoder(&buf).Encode(doc); err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(&buf)
+	dec.KnownFields(true)
+	if err := dec.Decode(target); err != nil {
+		return errors.Wrap(err, "failed to set fields")
+	}
+
+	return nil
+}
+
+// resetJSON replaces the target with the JSON value.
+func resetJSON(target interface{}, b []byte) error {
+	switch t := target.(type) {
+	case *domain.Language:
+		*t = domain.Language{}
+	case *domain.Processor:
+		*t = domain.Processor{}
+	case *domain.Task:
+		*t = domain.Task{}
+	}
+	return json.Unmarshal(b, target)
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+
+	profileAddLanguageCmd.Flags().StringArrayVar(&profileAddLanguageProcessorFiles, "import", nil, "import a processor from a JSON or YAML file")
+
+	for _, c := range []*cobra.Command{profileAddLanguageCmd, profileAddProcessorCmd, profileAddTaskCmd} {
+		c.Flags().StringArrayVar(&profileSets, "set", nil, "set a field. e.g. --set show_name=GCC")
+		profileAddCmd.AddCommand(c)
+	}
+	profileCmd.AddCommand(profileAddCmd)
+
+	profileRemoveCmd.AddCommand(profileRemoveLanguageCmd, profileRemoveProcessorCmd, profileRemoveTaskCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+
+	for _, c := range []*cobra.Command{profileEditLanguageCmd, profileEditProcessorCmd, profileEditTaskCmd} {
+		c.Flags().StringArrayVar(&profileSets, "set", nil, "set a field. e.g. --set show_name=GCC")
+		c.Flags().StringArrayVar(&profileUnsets, "unset", nil, "unset a field. e.g. --unset description")
+		profileEditCmd.AddCommand(c)
+	}
+	profileCmd.AddCommand(profileEditCmd)
+
+	profileImportCmd.Flags().BoolVar(&profileImportReplace, "replace", false, "replace the processor if it exists")
+	profileCmd.AddCommand(profileImportCmd)
+
+	profileDiffCmd.Flags().BoolVar(&profileDiffRaw, "raw", false, "compare profiles as written without expanding templates")
+	profileCmd.AddCommand(profileDiffCmd)
+}
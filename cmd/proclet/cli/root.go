@@ -14,7 +14,7 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.Flags().StringVar(&profilePath, "profilePath", "/tmp/proclet.profile.json", "profile path")
+	rootCmd.PersistentFlags().StringVar(&profilePath, "profilePath", "/tmp/proclet.profile.json", "profile path")
 }
 
 func Execute() {
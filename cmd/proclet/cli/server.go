@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,16 +18,44 @@ import (
 	"golang.org/x/net/http2/h2c"
 
 	apiv1 "github.com/yutopp/proclet/pkg/server"
+	"github.com/yutopp/proclet/pkg/service/container"
 )
 
 var uid int
 var gid int
+var cpuset string
+var pullImages bool
+var redactCommands bool
+var adminTokenFile string
+var fileLimits apiv1.FileLimits
+var artifactLimits apiv1.ArtifactLimits
+var artifactTTL time.Duration
+var artifactStoreMaxBytes int64
+var buildCacheDir string
+var buildCacheMaxBytes int64
 
 var logger = zap.Must(zap.NewDevelopment())
 
 func init() {
 	serverCmd.Flags().IntVar(&uid, "uid", 0, "runner uid")
 	serverCmd.Flags().IntVar(&gid, "gid", 0, "runner gid")
+	serverCmd.Flags().StringVar(&cpuset, "cpuset", "", "cores dedicated to runners (e.g. 1-3). all cores if empty")
+	serverCmd.Flags().BoolVar(&pullImages, "pullImages", true, "pull missing images when the profile is loaded")
+	serverCmd.Flags().BoolVar(&redactCommands, "redactCommands", false, "hide commands of tasks from List")
+	serverCmd.Flags().StringVar(&adminTokenFile, "adminTokenFile", "", "file of the bearer token for AdminService. AdminService is disabled if empty")
+	serverCmd.Flags().IntVar(&fileLimits.MaxFiles, "maxFiles", apiv1.DefaultFileLimits.MaxFiles, "max number of files per request")
+	serverCmd.Flags().Int64Var(&fileLimits.MaxTotalBytes, "maxTotalBytes", apiv1.DefaultFileLimits.MaxTotalBytes, "max total bytes of files per request")
+	serverCmd.Flags().Int64Var(&fileLimits.MaxFileBytes, "maxFileBytes", apiv1.DefaultFileLimits.MaxFileBytes, "max bytes per file")
+	serverCmd.Flags().IntVar(&fileLimits.MaxPathLength, "maxPathLength", apiv1.DefaultFileLimits.MaxPathLength, "max bytes of a file path")
+	serverCmd.Flags().IntVar(&fileLimits.MaxPathDepth, "maxPathDepth", apiv1.DefaultFileLimits.MaxPathDepth, "max number of components of a file path")
+	serverCmd.Flags().IntVar(&artifactLimits.MaxArtifacts, "maxArtifacts", apiv1.DefaultArtifactLimits.MaxArtifacts, "max number of artifacts per run")
+	serverCmd.Flags().Int64Var(&artifactLimits.MaxInlineBytes, "maxArtifactInlineBytes", apiv1.DefaultArtifactLimits.MaxInlineBytes, "max bytes of an artifact returned in the stream. larger ones are returned by handles")
+	serverCmd.Flags().Int64Var(&artifactLimits.MaxBytes, "maxArtifactBytes", apiv1.DefaultArtifactLimits.MaxBytes, "max bytes per artifact")
+	serverCmd.Flags().Int64Var(&artifactLimits.MaxTotalBytes, "maxArtifactTotalBytes", apiv1.DefaultArtifactLimits.MaxTotalBytes, "max total bytes of artifacts per run")
+	serverCmd.Flags().DurationVar(&artifactTTL, "artifactTTL", 10*time.Minute, "how long large artifacts can be downloaded")
+	serverCmd.Flags().Int64Var(&artifactStoreMaxBytes, "maxArtifactStoreBytes", 256*1024*1024, "max total bytes of large artifacts kept for downloads")
+	serverCmd.Flags().StringVar(&buildCacheDir, "buildCacheDir", "", "directory of the build cache. disabled if empty")
+	serverCmd.Flags().Int64Var(&buildCacheMaxBytes, "buildCacheMaxBytes", 1024*1024*1024, "max bytes of the build cache. least recently used entries are evicted")
 
 	rootCmd.AddCommand(serverCmd)
 }
@@ -36,23 +65,85 @@ var serverCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		port := 9000
 
+		var cpus []int
+		if cpuset != "" {
+			var err error
+			cpus, err = container.ParseCPUSet(cpuset)
+			if err != nil {
+				logger.Fatal("invalid cpuset", zap.Error(err))
+			}
+		}
+
 		logger.Info("start server", zap.Int("port", port))
-		run(port)
+		run(cmd.Context(), port, cpus)
 	},
 }
 
-func run(port int) {
+func run(ctx context.Context, port int, cpus []int) {
 	mux := http.NewServeMux()
 
+	adminToken := ""
+	if adminTokenFile != "" {
+		b, err := os.ReadFile(adminTokenFile)
+		if err != nil {
+			logger.Fatal("read admin token", zap.Error(err))
+		}
+		adminToken = strings.TrimSpace(string(b))
+		if adminToken == "" {
+			logger.Fatal("admin token is empty", zap.String("Path", adminTokenFile))
+		}
+	}
+
+	var buildCache *apiv1.BuildCache
+	if buildCacheDir != "" {
+		var err error
+		buildCache, err = apiv1.NewBuildCache(buildCacheDir, buildCacheMaxBytes)
+		if err != nil {
+			logger.Fatal("open build cache", zap.Error(err))
+		}
+	}
+
 	srv := apiv1.NewServer(&apiv1.Config{
 		ProfilePath: profilePath,
+		PullImages:  pullImages,
+
+		RedactCommands: redactCommands,
+		AdminToken:     adminToken,
 
 		RunnerUID: uid,
 		RunnerGID: gid,
 
+		CPUs: cpus,
+
+		FileLimits:            fileLimits,
+		ArtifactLimits:        artifactLimits,
+		ArtifactTTL:           artifactTTL,
+		ArtifactStoreMaxBytes: artifactStoreMaxBytes,
+		BuildCache:            buildCache,
+
 		Logger: logger,
 	})
+	if err := srv.LoadProfile(ctx); err != nil {
+		logger.Fatal("load profile", zap.Error(err))
+	}
 	apiv1.Register(mux, srv)
+	apiv1.RegisterAdmin(mux, srv)
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	go srv.WatchProfile(watchCtx)
+
+	// Reload the profile on SIGHUP
+	hupSignals := make(chan os.Signal, 1)
+	signal.Notify(hupSignals, syscall.SIGHUP)
+	go func() {
+		for range hupSignals {
+			logger.Info("SIGHUP received, reloading the profile")
+			if err := srv.LoadProfile(watchCtx); err != nil {
+				logger.Error("reload profile", zap.Error(err))
+			}
+		}
+	}()
 
 	corsHandler := cors.New(cors.Options{
 		AllowedMethods: []string{
@@ -62,6 +153,7 @@ func run(port int) {
 		AllowedOrigins: []string{"*"},
 		AllowedHeaders: []string{
 			"Accept-Encoding",
+			"Authorization", // Used for AdminService
 			"Content-Encoding",
 			"Content-Type",
 			"Connect-Protocol-Version",
@@ -102,9 +194,10 @@ func run(port int) {
 	}()
 
 	<-signals
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	if err := httpSrv.Shutdown(ctx); err != nil {
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
 		logger.Fatal("HTTP shutdown", zap.Error(err))
 	}
+	srv.Close()
 }
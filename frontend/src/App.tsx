@@ -86,6 +86,15 @@ function App() {
             // TODO: stderr
             termRef.current.term.write(message.response.value.buffer);
             break;
+          case "warning":
+            termRef.current.term.write(`\x1b[33m${message.response.value.message}\x1b[0m\r\n`);
+            break;
+          case "artifact": {
+            const artifact = message.response.value;
+            const note = artifact.omittedReason !== "" ? ` omitted: ${artifact.omittedReason}` : "";
+            termRef.current.term.write(`\x1b[36martifact: ${artifact.path} (${artifact.size} bytes)${note}\x1b[0m\r\n`);
+            break;
+          }
         }
       }
     }
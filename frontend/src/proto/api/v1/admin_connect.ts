@@ -0,0 +1,122 @@
+// @generated by protoc-gen-connect-es v1.2.0 with parameter "target=ts"
+// @generated from file proto/api/v1/admin.proto (package proto.api.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import { Empty, MethodKind } from "@bufbuild/protobuf";
+import { GetProfileResponse, CreateLanguageRequest, UpdateLanguageRequest, DeleteLanguageRequest, CreateProcessorRequest, UpdateProcessorRequest, DeleteProcessorRequest, CreateTaskRequest, UpdateTaskRequest, DeleteTaskRequest, SetEnabledRequest } from "./admin_pb.js";
+
+/**
+ * AdminService manages the catalog at runtime. Requests must have "Authorization: Bearer <token>".
+ * Definitions mirror the profile schema (`proclet profile schema`).
+ * Changes are validated, saved to the profile and served without restarting.
+ *
+ * @generated from service proto.api.v1.AdminService
+ */
+export const AdminService = {
+  typeName: "proto.api.v1.AdminService",
+  methods: {
+    /**
+     * @generated from rpc proto.api.v1.AdminService.GetProfile
+     */
+    getProfile: {
+      name: "GetProfile",
+      I: Empty,
+      O: GetProfileResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.CreateLanguage
+     */
+    createLanguage: {
+      name: "CreateLanguage",
+      I: CreateLanguageRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.UpdateLanguage
+     */
+    updateLanguage: {
+      name: "UpdateLanguage",
+      I: UpdateLanguageRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.DeleteLanguage
+     */
+    deleteLanguage: {
+      name: "DeleteLanguage",
+      I: DeleteLanguageRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.CreateProcessor
+     */
+    createProcessor: {
+      name: "CreateProcessor",
+      I: CreateProcessorRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.UpdateProcessor
+     */
+    updateProcessor: {
+      name: "UpdateProcessor",
+      I: UpdateProcessorRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.DeleteProcessor
+     */
+    deleteProcessor: {
+      name: "DeleteProcessor",
+      I: DeleteProcessorRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.CreateTask
+     */
+    createTask: {
+      name: "CreateTask",
+      I: CreateTaskRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.UpdateTask
+     */
+    updateTask: {
+      name: "UpdateTask",
+      I: UpdateTaskRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * @generated from rpc proto.api.v1.AdminService.DeleteTask
+     */
+    deleteTask: {
+      name: "DeleteTask",
+      I: DeleteTaskRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * SetEnabled enables or disables the task if task_id is set, else the processor if processor_id is set, else the language.
+     *
+     * @generated from rpc proto.api.v1.AdminService.SetEnabled
+     */
+    setEnabled: {
+      name: "SetEnabled",
+      I: SetEnabledRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
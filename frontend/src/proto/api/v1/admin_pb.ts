@@ -0,0 +1,1147 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file proto/api/v1/admin.proto (package proto.api.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64 } from "@bufbuild/protobuf";
+
+/**
+ * @generated from message proto.api.v1.GetProfileResponse
+ */
+export class GetProfileResponse extends Message<GetProfileResponse> {
+  /**
+   * as written, templates are not expanded
+   *
+   * @generated from field: proto.api.v1.ProfileDefinition profile = 1;
+   */
+  profile?: ProfileDefinition;
+
+  constructor(data?: PartialMessage<GetProfileResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.GetProfileResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "profile", kind: "message", T: ProfileDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetProfileResponse {
+    return new GetProfileResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetProfileResponse {
+    return new GetProfileResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetProfileResponse {
+    return new GetProfileResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetProfileResponse | PlainMessage<GetProfileResponse> | undefined, b: GetProfileResponse | PlainMessage<GetProfileResponse> | undefined): boolean {
+    return proto3.util.equals(GetProfileResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.CreateLanguageRequest
+ */
+export class CreateLanguageRequest extends Message<CreateLanguageRequest> {
+  /**
+   * @generated from field: proto.api.v1.LanguageDefinition language = 1;
+   */
+  language?: LanguageDefinition;
+
+  constructor(data?: PartialMessage<CreateLanguageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.CreateLanguageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language", kind: "message", T: LanguageDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateLanguageRequest {
+    return new CreateLanguageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateLanguageRequest {
+    return new CreateLanguageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateLanguageRequest {
+    return new CreateLanguageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateLanguageRequest | PlainMessage<CreateLanguageRequest> | undefined, b: CreateLanguageRequest | PlainMessage<CreateLanguageRequest> | undefined): boolean {
+    return proto3.util.equals(CreateLanguageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.UpdateLanguageRequest
+ */
+export class UpdateLanguageRequest extends Message<UpdateLanguageRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * processors are kept if empty
+   *
+   * @generated from field: proto.api.v1.LanguageDefinition language = 2;
+   */
+  language?: LanguageDefinition;
+
+  constructor(data?: PartialMessage<UpdateLanguageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.UpdateLanguageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "language", kind: "message", T: LanguageDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateLanguageRequest {
+    return new UpdateLanguageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateLanguageRequest {
+    return new UpdateLanguageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateLanguageRequest {
+    return new UpdateLanguageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateLanguageRequest | PlainMessage<UpdateLanguageRequest> | undefined, b: UpdateLanguageRequest | PlainMessage<UpdateLanguageRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateLanguageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.DeleteLanguageRequest
+ */
+export class DeleteLanguageRequest extends Message<DeleteLanguageRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  constructor(data?: PartialMessage<DeleteLanguageRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.DeleteLanguageRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteLanguageRequest {
+    return new DeleteLanguageRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteLanguageRequest {
+    return new DeleteLanguageRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteLanguageRequest {
+    return new DeleteLanguageRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteLanguageRequest | PlainMessage<DeleteLanguageRequest> | undefined, b: DeleteLanguageRequest | PlainMessage<DeleteLanguageRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteLanguageRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.CreateProcessorRequest
+ */
+export class CreateProcessorRequest extends Message<CreateProcessorRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: proto.api.v1.ProcessorDefinition processor = 2;
+   */
+  processor?: ProcessorDefinition;
+
+  constructor(data?: PartialMessage<CreateProcessorRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.CreateProcessorRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor", kind: "message", T: ProcessorDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateProcessorRequest {
+    return new CreateProcessorRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateProcessorRequest {
+    return new CreateProcessorRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateProcessorRequest {
+    return new CreateProcessorRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateProcessorRequest | PlainMessage<CreateProcessorRequest> | undefined, b: CreateProcessorRequest | PlainMessage<CreateProcessorRequest> | undefined): boolean {
+    return proto3.util.equals(CreateProcessorRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.UpdateProcessorRequest
+ */
+export class UpdateProcessorRequest extends Message<UpdateProcessorRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  /**
+   * tasks are kept if empty
+   *
+   * @generated from field: proto.api.v1.ProcessorDefinition processor = 3;
+   */
+  processor?: ProcessorDefinition;
+
+  constructor(data?: PartialMessage<UpdateProcessorRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.UpdateProcessorRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "processor", kind: "message", T: ProcessorDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateProcessorRequest {
+    return new UpdateProcessorRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateProcessorRequest {
+    return new UpdateProcessorRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateProcessorRequest {
+    return new UpdateProcessorRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateProcessorRequest | PlainMessage<UpdateProcessorRequest> | undefined, b: UpdateProcessorRequest | PlainMessage<UpdateProcessorRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateProcessorRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.DeleteProcessorRequest
+ */
+export class DeleteProcessorRequest extends Message<DeleteProcessorRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  constructor(data?: PartialMessage<DeleteProcessorRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.DeleteProcessorRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteProcessorRequest {
+    return new DeleteProcessorRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteProcessorRequest {
+    return new DeleteProcessorRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteProcessorRequest {
+    return new DeleteProcessorRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteProcessorRequest | PlainMessage<DeleteProcessorRequest> | undefined, b: DeleteProcessorRequest | PlainMessage<DeleteProcessorRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteProcessorRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.CreateTaskRequest
+ */
+export class CreateTaskRequest extends Message<CreateTaskRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  /**
+   * @generated from field: proto.api.v1.TaskDefinition task = 3;
+   */
+  task?: TaskDefinition;
+
+  constructor(data?: PartialMessage<CreateTaskRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.CreateTaskRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task", kind: "message", T: TaskDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateTaskRequest {
+    return new CreateTaskRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateTaskRequest {
+    return new CreateTaskRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateTaskRequest {
+    return new CreateTaskRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateTaskRequest | PlainMessage<CreateTaskRequest> | undefined, b: CreateTaskRequest | PlainMessage<CreateTaskRequest> | undefined): boolean {
+    return proto3.util.equals(CreateTaskRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.UpdateTaskRequest
+ */
+export class UpdateTaskRequest extends Message<UpdateTaskRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  /**
+   * @generated from field: string task_id = 3;
+   */
+  taskId = "";
+
+  /**
+   * @generated from field: proto.api.v1.TaskDefinition task = 4;
+   */
+  task?: TaskDefinition;
+
+  constructor(data?: PartialMessage<UpdateTaskRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.UpdateTaskRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "task", kind: "message", T: TaskDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateTaskRequest {
+    return new UpdateTaskRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateTaskRequest {
+    return new UpdateTaskRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateTaskRequest {
+    return new UpdateTaskRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateTaskRequest | PlainMessage<UpdateTaskRequest> | undefined, b: UpdateTaskRequest | PlainMessage<UpdateTaskRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateTaskRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.DeleteTaskRequest
+ */
+export class DeleteTaskRequest extends Message<DeleteTaskRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  /**
+   * @generated from field: string task_id = 3;
+   */
+  taskId = "";
+
+  constructor(data?: PartialMessage<DeleteTaskRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.DeleteTaskRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteTaskRequest {
+    return new DeleteTaskRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteTaskRequest {
+    return new DeleteTaskRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteTaskRequest {
+    return new DeleteTaskRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteTaskRequest | PlainMessage<DeleteTaskRequest> | undefined, b: DeleteTaskRequest | PlainMessage<DeleteTaskRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteTaskRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.SetEnabledRequest
+ */
+export class SetEnabledRequest extends Message<SetEnabledRequest> {
+  /**
+   * @generated from field: string language_id = 1;
+   */
+  languageId = "";
+
+  /**
+   * @generated from field: string processor_id = 2;
+   */
+  processorId = "";
+
+  /**
+   * @generated from field: string task_id = 3;
+   */
+  taskId = "";
+
+  /**
+   * @generated from field: bool enabled = 4;
+   */
+  enabled = false;
+
+  constructor(data?: PartialMessage<SetEnabledRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.SetEnabledRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "enabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SetEnabledRequest {
+    return new SetEnabledRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SetEnabledRequest {
+    return new SetEnabledRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SetEnabledRequest {
+    return new SetEnabledRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SetEnabledRequest | PlainMessage<SetEnabledRequest> | undefined, b: SetEnabledRequest | PlainMessage<SetEnabledRequest> | undefined): boolean {
+    return proto3.util.equals(SetEnabledRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.ProfileDefinition
+ */
+export class ProfileDefinition extends Message<ProfileDefinition> {
+  /**
+   * @generated from field: repeated proto.api.v1.LanguageDefinition languages = 1;
+   */
+  languages: LanguageDefinition[] = [];
+
+  /**
+   * @generated from field: repeated proto.api.v1.ProcessorDefinition templates = 2;
+   */
+  templates: ProcessorDefinition[] = [];
+
+  constructor(data?: PartialMessage<ProfileDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.ProfileDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "languages", kind: "message", T: LanguageDefinition, repeated: true },
+    { no: 2, name: "templates", kind: "message", T: ProcessorDefinition, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ProfileDefinition {
+    return new ProfileDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ProfileDefinition {
+    return new ProfileDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ProfileDefinition {
+    return new ProfileDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ProfileDefinition | PlainMessage<ProfileDefinition> | undefined, b: ProfileDefinition | PlainMessage<ProfileDefinition> | undefined): boolean {
+    return proto3.util.equals(ProfileDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.LanguageDefinition
+ */
+export class LanguageDefinition extends Message<LanguageDefinition> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.ProcessorDefinition processors = 3;
+   */
+  processors: ProcessorDefinition[] = [];
+
+  /**
+   * @generated from field: string description = 4;
+   */
+  description = "";
+
+  /**
+   * @generated from field: repeated string file_extensions = 5;
+   */
+  fileExtensions: string[] = [];
+
+  /**
+   * @generated from field: repeated string shebangs = 6;
+   */
+  shebangs: string[] = [];
+
+  /**
+   * @generated from field: repeated string aliases = 7;
+   */
+  aliases: string[] = [];
+
+  /**
+   * @generated from field: string default_processor = 8;
+   */
+  defaultProcessor = "";
+
+  /**
+   * @generated from field: bool disabled = 9;
+   */
+  disabled = false;
+
+  constructor(data?: PartialMessage<LanguageDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.LanguageDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "processors", kind: "message", T: ProcessorDefinition, repeated: true },
+    { no: 4, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "file_extensions", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "shebangs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "aliases", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "default_processor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "disabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): LanguageDefinition {
+    return new LanguageDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): LanguageDefinition {
+    return new LanguageDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): LanguageDefinition {
+    return new LanguageDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: LanguageDefinition | PlainMessage<LanguageDefinition> | undefined, b: LanguageDefinition | PlainMessage<LanguageDefinition> | undefined): boolean {
+    return proto3.util.equals(LanguageDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.ProcessorDefinition
+ */
+export class ProcessorDefinition extends Message<ProcessorDefinition> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string version = 4;
+   */
+  version = "";
+
+  /**
+   * @generated from field: repeated string version_cmd = 5;
+   */
+  versionCmd: string[] = [];
+
+  /**
+   * @generated from field: string docker_image = 6;
+   */
+  dockerImage = "";
+
+  /**
+   * @generated from field: string docker_image_digest = 7;
+   */
+  dockerImageDigest = "";
+
+  /**
+   * @generated from field: string default_filename = 8;
+   */
+  defaultFilename = "";
+
+  /**
+   * @generated from field: string output_filename = 9;
+   */
+  outputFilename = "";
+
+  /**
+   * @generated from field: string sample_code = 10;
+   */
+  sampleCode = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.TaskDefinition tasks = 11;
+   */
+  tasks: TaskDefinition[] = [];
+
+  /**
+   * @generated from field: string default_task = 12;
+   */
+  defaultTask = "";
+
+  /**
+   * @generated from field: string extends = 13;
+   */
+  extends = "";
+
+  /**
+   * @generated from field: map<string, string> vars = 14;
+   */
+  vars: { [key: string]: string } = {};
+
+  /**
+   * "active" if empty, "deprecated" or "disabled"
+   *
+   * @generated from field: string status = 15;
+   */
+  status = "";
+
+  /**
+   * @generated from field: string status_message = 16;
+   */
+  statusMessage = "";
+
+  /**
+   * @generated from field: string replaced_by = 17;
+   */
+  replacedBy = "";
+
+  constructor(data?: PartialMessage<ProcessorDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.ProcessorDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "version_cmd", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "docker_image", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "docker_image_digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "default_filename", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "output_filename", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "sample_code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "tasks", kind: "message", T: TaskDefinition, repeated: true },
+    { no: 12, name: "default_task", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 13, name: "extends", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 14, name: "vars", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
+    { no: 15, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 16, name: "status_message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 17, name: "replaced_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ProcessorDefinition {
+    return new ProcessorDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ProcessorDefinition {
+    return new ProcessorDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ProcessorDefinition {
+    return new ProcessorDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ProcessorDefinition | PlainMessage<ProcessorDefinition> | undefined, b: ProcessorDefinition | PlainMessage<ProcessorDefinition> | undefined): boolean {
+    return proto3.util.equals(ProcessorDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.TaskDefinition
+ */
+export class TaskDefinition extends Message<TaskDefinition> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * "action" or "tool"
+   *
+   * @generated from field: string kind = 4;
+   */
+  kind = "";
+
+  /**
+   * @generated from field: proto.api.v1.PhaseDefinition compile = 5;
+   */
+  compile?: PhaseDefinition;
+
+  /**
+   * @generated from field: proto.api.v1.PhaseDefinition run = 6;
+   */
+  run?: PhaseDefinition;
+
+  /**
+   * @generated from field: repeated proto.api.v1.OptionGroupDefinition option_groups = 7;
+   */
+  optionGroups: OptionGroupDefinition[] = [];
+
+  /**
+   * @generated from field: bool allow_run_args = 8;
+   */
+  allowRunArgs = false;
+
+  /**
+   * @generated from field: repeated string artifacts = 9;
+   */
+  artifacts: string[] = [];
+
+  /**
+   * @generated from field: string status = 10;
+   */
+  status = "";
+
+  /**
+   * @generated from field: string status_message = 11;
+   */
+  statusMessage = "";
+
+  /**
+   * @generated from field: string replaced_by = 12;
+   */
+  replacedBy = "";
+
+  constructor(data?: PartialMessage<TaskDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.TaskDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "compile", kind: "message", T: PhaseDefinition },
+    { no: 6, name: "run", kind: "message", T: PhaseDefinition },
+    { no: 7, name: "option_groups", kind: "message", T: OptionGroupDefinition, repeated: true },
+    { no: 8, name: "allow_run_args", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "artifacts", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 10, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "status_message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 12, name: "replaced_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): TaskDefinition {
+    return new TaskDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): TaskDefinition {
+    return new TaskDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): TaskDefinition {
+    return new TaskDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: TaskDefinition | PlainMessage<TaskDefinition> | undefined, b: TaskDefinition | PlainMessage<TaskDefinition> | undefined): boolean {
+    return proto3.util.equals(TaskDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.OptionGroupDefinition
+ */
+export class OptionGroupDefinition extends Message<OptionGroupDefinition> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.OptionChoiceDefinition choices = 3;
+   */
+  choices: OptionChoiceDefinition[] = [];
+
+  /**
+   * @generated from field: repeated string default = 4;
+   */
+  default: string[] = [];
+
+  /**
+   * @generated from field: bool multiple = 5;
+   */
+  multiple = false;
+
+  /**
+   * @generated from field: bool free_form = 6;
+   */
+  freeForm = false;
+
+  /**
+   * @generated from field: repeated string safelist = 7;
+   */
+  safelist: string[] = [];
+
+  constructor(data?: PartialMessage<OptionGroupDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.OptionGroupDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "choices", kind: "message", T: OptionChoiceDefinition, repeated: true },
+    { no: 4, name: "default", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "multiple", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "free_form", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "safelist", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OptionGroupDefinition {
+    return new OptionGroupDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OptionGroupDefinition {
+    return new OptionGroupDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OptionGroupDefinition {
+    return new OptionGroupDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OptionGroupDefinition | PlainMessage<OptionGroupDefinition> | undefined, b: OptionGroupDefinition | PlainMessage<OptionGroupDefinition> | undefined): boolean {
+    return proto3.util.equals(OptionGroupDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.OptionChoiceDefinition
+ */
+export class OptionChoiceDefinition extends Message<OptionChoiceDefinition> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated string args = 3;
+   */
+  args: string[] = [];
+
+  constructor(data?: PartialMessage<OptionChoiceDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.OptionChoiceDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "args", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OptionChoiceDefinition {
+    return new OptionChoiceDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OptionChoiceDefinition {
+    return new OptionChoiceDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OptionChoiceDefinition {
+    return new OptionChoiceDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OptionChoiceDefinition | PlainMessage<OptionChoiceDefinition> | undefined, b: OptionChoiceDefinition | PlainMessage<OptionChoiceDefinition> | undefined): boolean {
+    return proto3.util.equals(OptionChoiceDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.PhaseDefinition
+ */
+export class PhaseDefinition extends Message<PhaseDefinition> {
+  /**
+   * @generated from field: repeated string cmd = 1;
+   */
+  cmd: string[] = [];
+
+  /**
+   * @generated from field: bool shell = 2;
+   */
+  shell = false;
+
+  /**
+   * @generated from field: map<string, string> env = 3;
+   */
+  env: { [key: string]: string } = {};
+
+  /**
+   * @generated from field: repeated string allowed_env = 4;
+   */
+  allowedEnv: string[] = [];
+
+  /**
+   * @generated from field: proto.api.v1.LimitsDefinition limits = 5;
+   */
+  limits?: LimitsDefinition;
+
+  constructor(data?: PartialMessage<PhaseDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.PhaseDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cmd", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "shell", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "env", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
+    { no: 4, name: "allowed_env", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "limits", kind: "message", T: LimitsDefinition },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PhaseDefinition {
+    return new PhaseDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): PhaseDefinition {
+    return new PhaseDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): PhaseDefinition {
+    return new PhaseDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: PhaseDefinition | PlainMessage<PhaseDefinition> | undefined, b: PhaseDefinition | PlainMessage<PhaseDefinition> | undefined): boolean {
+    return proto3.util.equals(PhaseDefinition, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.LimitsDefinition
+ */
+export class LimitsDefinition extends Message<LimitsDefinition> {
+  /**
+   * sec
+   *
+   * @generated from field: int64 cpu_time = 1;
+   */
+  cpuTime = protoInt64.zero;
+
+  /**
+   * sec
+   *
+   * @generated from field: int64 wall_time = 2;
+   */
+  wallTime = protoInt64.zero;
+
+  /**
+   * bytes
+   *
+   * @generated from field: int64 memory = 3;
+   */
+  memory = protoInt64.zero;
+
+  /**
+   * bytes, memory + swap
+   *
+   * @generated from field: int64 memory_swap = 4;
+   */
+  memorySwap = protoInt64.zero;
+
+  /**
+   * microsec per cpu_period
+   *
+   * @generated from field: int64 cpu_quota = 5;
+   */
+  cpuQuota = protoInt64.zero;
+
+  /**
+   * microsec
+   *
+   * @generated from field: int64 cpu_period = 6;
+   */
+  cpuPeriod = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 cores = 7;
+   */
+  cores = protoInt64.zero;
+
+  constructor(data?: PartialMessage<LimitsDefinition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.LimitsDefinition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cpu_time", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "wall_time", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "memory", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "memory_swap", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "cpu_quota", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "cpu_period", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "cores", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): LimitsDefinition {
+    return new LimitsDefinition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): LimitsDefinition {
+    return new LimitsDefinition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): LimitsDefinition {
+    return new LimitsDefinition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: LimitsDefinition | PlainMessage<LimitsDefinition> | undefined, b: LimitsDefinition | PlainMessage<LimitsDefinition> | undefined): boolean {
+    return proto3.util.equals(LimitsDefinition, a, b);
+  }
+}
+
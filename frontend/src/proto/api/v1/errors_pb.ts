@@ -0,0 +1,55 @@
+// @generated by protoc-gen-es v1.6.0 with parameter "target=ts"
+// @generated from file proto/api/v1/errors.proto (package proto.api.v1, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3 } from "@bufbuild/protobuf";
+
+/**
+ * FieldViolation is attached to errors as a detail to tell which field of the request is wrong.
+ *
+ * @generated from message proto.api.v1.FieldViolation
+ */
+export class FieldViolation extends Message<FieldViolation> {
+  /**
+   * e.g. "options[0].choice_ids[1]"
+   *
+   * @generated from field: string field = 1;
+   */
+  field = "";
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description = "";
+
+  constructor(data?: PartialMessage<FieldViolation>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.FieldViolation";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "field", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): FieldViolation {
+    return new FieldViolation().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): FieldViolation {
+    return new FieldViolation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): FieldViolation {
+    return new FieldViolation().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: FieldViolation | PlainMessage<FieldViolation> | undefined, b: FieldViolation | PlainMessage<FieldViolation> | undefined): boolean {
+    return proto3.util.equals(FieldViolation, a, b);
+  }
+}
+
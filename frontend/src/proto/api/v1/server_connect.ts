@@ -4,7 +4,7 @@
 // @ts-nocheck
 
 import { Empty, MethodKind } from "@bufbuild/protobuf";
-import { ListResponse, RunOneshotRequest, RunOneshotResponse } from "./server_pb.js";
+import { ListResponse, RunOneshotRequest, RunOneshotResponse, ResolveRequest, ResolveResponse, DownloadArtifactRequest, DownloadArtifactResponse } from "./server_pb.js";
 
 /**
  * @generated from service proto.api.v1.RunnerService
@@ -30,6 +30,28 @@ export const RunnerService = {
       O: RunOneshotResponse,
       kind: MethodKind.ServerStreaming,
     },
+    /**
+     * Resolve tells which language, processor and task RunOneshot would use for the request.
+     *
+     * @generated from rpc proto.api.v1.RunnerService.Resolve
+     */
+    resolve: {
+      name: "Resolve",
+      I: ResolveRequest,
+      O: ResolveResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * DownloadArtifact streams content of an artifact by the handle until it expires.
+     *
+     * @generated from rpc proto.api.v1.RunnerService.DownloadArtifact
+     */
+    downloadArtifact: {
+      name: "DownloadArtifact",
+      I: DownloadArtifactRequest,
+      O: DownloadArtifactResponse,
+      kind: MethodKind.ServerStreaming,
+    },
   }
 } as const;
 
@@ -48,16 +48,22 @@ export class ListResponse extends Message<ListResponse> {
  */
 export class RunOneshotRequest extends Message<RunOneshotRequest> {
   /**
+   * ID or alias. detected from files if empty
+   *
    * @generated from field: string language_id = 1;
    */
   languageId = "";
 
   /**
+   * default processor of the language if empty
+   *
    * @generated from field: string processor_id = 2;
    */
   processorId = "";
 
   /**
+   * default task of the processor if empty
+   *
    * @generated from field: string task_id = 3;
    */
   taskId = "";
@@ -67,6 +73,32 @@ export class RunOneshotRequest extends Message<RunOneshotRequest> {
    */
   files: File[] = [];
 
+  /**
+   * must be allowed by the task
+   *
+   * @generated from field: map<string, string> env = 5;
+   */
+  env: { [key: string]: string } = {};
+
+  /**
+   * @generated from field: repeated proto.api.v1.SelectedOption options = 6;
+   */
+  options: SelectedOption[] = [];
+
+  /**
+   * must be allowed by the task
+   *
+   * @generated from field: repeated string run_args = 7;
+   */
+  runArgs: string[] = [];
+
+  /**
+   * extracted in addition to files
+   *
+   * @generated from field: proto.api.v1.Archive archive = 8;
+   */
+  archive?: Archive;
+
   constructor(data?: PartialMessage<RunOneshotRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -79,6 +111,10 @@ export class RunOneshotRequest extends Message<RunOneshotRequest> {
     { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 4, name: "files", kind: "message", T: File, repeated: true },
+    { no: 5, name: "env", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
+    { no: 6, name: "options", kind: "message", T: SelectedOption, repeated: true },
+    { no: 7, name: "run_args", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "archive", kind: "message", T: Archive },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RunOneshotRequest {
@@ -98,6 +134,57 @@ export class RunOneshotRequest extends Message<RunOneshotRequest> {
   }
 }
 
+/**
+ * @generated from message proto.api.v1.SelectedOption
+ */
+export class SelectedOption extends Message<SelectedOption> {
+  /**
+   * @generated from field: string group_id = 1;
+   */
+  groupId = "";
+
+  /**
+   * @generated from field: repeated string choice_ids = 2;
+   */
+  choiceIds: string[] = [];
+
+  /**
+   * free-form options
+   *
+   * @generated from field: repeated string values = 3;
+   */
+  values: string[] = [];
+
+  constructor(data?: PartialMessage<SelectedOption>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.SelectedOption";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "group_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "choice_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "values", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SelectedOption {
+    return new SelectedOption().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SelectedOption {
+    return new SelectedOption().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SelectedOption {
+    return new SelectedOption().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SelectedOption | PlainMessage<SelectedOption> | undefined, b: SelectedOption | PlainMessage<SelectedOption> | undefined): boolean {
+    return proto3.util.equals(SelectedOption, a, b);
+  }
+}
+
 /**
  * @generated from message proto.api.v1.RunOneshotResponse
  */
@@ -116,6 +203,36 @@ export class RunOneshotResponse extends Message<RunOneshotResponse> {
      */
     value: Output;
     case: "output";
+  } | {
+    /**
+     * @generated from field: proto.api.v1.Result result = 3;
+     */
+    value: Result;
+    case: "result";
+  } | {
+    /**
+     * sent before phases, or before artifacts
+     *
+     * @generated from field: proto.api.v1.Warning warning = 4;
+     */
+    value: Warning;
+    case: "warning";
+  } | {
+    /**
+     * sent first
+     *
+     * @generated from field: proto.api.v1.Resolution resolution = 5;
+     */
+    value: Resolution;
+    case: "resolution";
+  } | {
+    /**
+     * sent last
+     *
+     * @generated from field: proto.api.v1.Artifact artifact = 6;
+     */
+    value: Artifact;
+    case: "artifact";
   } | { case: undefined; value?: undefined } = { case: undefined };
 
   constructor(data?: PartialMessage<RunOneshotResponse>) {
@@ -128,6 +245,10 @@ export class RunOneshotResponse extends Message<RunOneshotResponse> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "phase", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "output", kind: "message", T: Output, oneof: "response" },
+    { no: 3, name: "result", kind: "message", T: Result, oneof: "response" },
+    { no: 4, name: "warning", kind: "message", T: Warning, oneof: "response" },
+    { no: 5, name: "resolution", kind: "message", T: Resolution, oneof: "response" },
+    { no: 6, name: "artifact", kind: "message", T: Artifact, oneof: "response" },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RunOneshotResponse {
@@ -148,284 +269,1129 @@ export class RunOneshotResponse extends Message<RunOneshotResponse> {
 }
 
 /**
- * @generated from message proto.api.v1.File
+ * @generated from message proto.api.v1.ResolveRequest
  */
-export class File extends Message<File> {
+export class ResolveRequest extends Message<ResolveRequest> {
   /**
-   * @generated from field: string path = 1;
+   * @generated from field: string language_id = 1;
    */
-  path = "";
+  languageId = "";
 
   /**
-   * @generated from field: bytes content = 2;
+   * @generated from field: string processor_id = 2;
    */
-  content = new Uint8Array(0);
+  processorId = "";
 
-  constructor(data?: PartialMessage<File>) {
+  /**
+   * @generated from field: string task_id = 3;
+   */
+  taskId = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.File files = 4;
+   */
+  files: File[] = [];
+
+  /**
+   * @generated from field: proto.api.v1.Archive archive = 5;
+   */
+  archive?: Archive;
+
+  constructor(data?: PartialMessage<ResolveRequest>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "proto.api.v1.File";
+  static readonly typeName = "proto.api.v1.ResolveRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "content", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "files", kind: "message", T: File, repeated: true },
+    { no: 5, name: "archive", kind: "message", T: Archive },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): File {
-    return new File().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResolveRequest {
+    return new ResolveRequest().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): File {
-    return new File().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResolveRequest {
+    return new ResolveRequest().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): File {
-    return new File().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResolveRequest {
+    return new ResolveRequest().fromJsonString(jsonString, options);
   }
 
-  static equals(a: File | PlainMessage<File> | undefined, b: File | PlainMessage<File> | undefined): boolean {
-    return proto3.util.equals(File, a, b);
+  static equals(a: ResolveRequest | PlainMessage<ResolveRequest> | undefined, b: ResolveRequest | PlainMessage<ResolveRequest> | undefined): boolean {
+    return proto3.util.equals(ResolveRequest, a, b);
   }
 }
 
 /**
- * @generated from message proto.api.v1.Output
+ * @generated from message proto.api.v1.ResolveResponse
  */
-export class Output extends Message<Output> {
+export class ResolveResponse extends Message<ResolveResponse> {
   /**
-   * 0 = stdout, 1 = stderr
-   *
-   * @generated from field: int64 kind = 1;
-   */
-  kind = protoInt64.zero;
-
-  /**
-   * utf8
-   *
-   * @generated from field: bytes buffer = 2;
+   * @generated from field: proto.api.v1.Resolution resolution = 1;
    */
-  buffer = new Uint8Array(0);
+  resolution?: Resolution;
 
-  constructor(data?: PartialMessage<Output>) {
+  constructor(data?: PartialMessage<ResolveResponse>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "proto.api.v1.Output";
+  static readonly typeName = "proto.api.v1.ResolveResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "kind", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
-    { no: 2, name: "buffer", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 1, name: "resolution", kind: "message", T: Resolution },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Output {
-    return new Output().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResolveResponse {
+    return new ResolveResponse().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Output {
-    return new Output().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResolveResponse {
+    return new ResolveResponse().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Output {
-    return new Output().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResolveResponse {
+    return new ResolveResponse().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Output | PlainMessage<Output> | undefined, b: Output | PlainMessage<Output> | undefined): boolean {
-    return proto3.util.equals(Output, a, b);
+  static equals(a: ResolveResponse | PlainMessage<ResolveResponse> | undefined, b: ResolveResponse | PlainMessage<ResolveResponse> | undefined): boolean {
+    return proto3.util.equals(ResolveResponse, a, b);
   }
 }
 
 /**
- * @generated from message proto.api.v1.Language
+ * @generated from message proto.api.v1.Resolution
  */
-export class Language extends Message<Language> {
+export class Resolution extends Message<Resolution> {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: string language_id = 1;
    */
-  id = "";
+  languageId = "";
 
   /**
-   * @generated from field: string show_name = 2;
+   * @generated from field: string processor_id = 2;
    */
-  showName = "";
+  processorId = "";
 
   /**
-   * @generated from field: repeated proto.api.v1.Processor processors = 3;
+   * @generated from field: string task_id = 3;
    */
-  processors: Processor[] = [];
+  taskId = "";
 
-  constructor(data?: PartialMessage<Language>) {
+  /**
+   * how the language is resolved: "id" | "alias" | "shebang" | "file_extension"
+   *
+   * @generated from field: string matched_by = 4;
+   */
+  matchedBy = "";
+
+  /**
+   * path of the file the language is detected from
+   *
+   * @generated from field: string matched_file = 5;
+   */
+  matchedFile = "";
+
+  constructor(data?: PartialMessage<Resolution>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "proto.api.v1.Language";
+  static readonly typeName = "proto.api.v1.Resolution";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "processors", kind: "message", T: Processor, repeated: true },
+    { no: 1, name: "language_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "matched_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "matched_file", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Language {
-    return new Language().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Resolution {
+    return new Resolution().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Language {
-    return new Language().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Resolution {
+    return new Resolution().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Language {
-    return new Language().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Resolution {
+    return new Resolution().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Language | PlainMessage<Language> | undefined, b: Language | PlainMessage<Language> | undefined): boolean {
-    return proto3.util.equals(Language, a, b);
+  static equals(a: Resolution | PlainMessage<Resolution> | undefined, b: Resolution | PlainMessage<Resolution> | undefined): boolean {
+    return proto3.util.equals(Resolution, a, b);
   }
 }
 
 /**
- * @generated from message proto.api.v1.Processor
+ * @generated from message proto.api.v1.Warning
  */
-export class Processor extends Message<Processor> {
-  /**
-   * @generated from field: string id = 1;
-   */
-  id = "";
-
-  /**
-   * @generated from field: string show_name = 2;
-   */
-  showName = "";
-
+export class Warning extends Message<Warning> {
   /**
-   * @generated from field: string description = 3;
+   * "deprecated" | "artifacts_truncated"
+   *
+   * @generated from field: string kind = 1;
    */
-  description = "";
+  kind = "";
 
   /**
-   * @generated from field: string default_filename = 4;
+   * @generated from field: string message = 2;
    */
-  defaultFilename = "";
+  message = "";
 
   /**
-   * @generated from field: repeated proto.api.v1.Task tasks = 5;
+   * @generated from field: string replaced_by = 3;
    */
-  tasks: Task[] = [];
+  replacedBy = "";
 
-  constructor(data?: PartialMessage<Processor>) {
+  constructor(data?: PartialMessage<Warning>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "proto.api.v1.Processor";
+  static readonly typeName = "proto.api.v1.Warning";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "default_filename", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 5, name: "tasks", kind: "message", T: Task, repeated: true },
+    { no: 1, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "replaced_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Processor {
-    return new Processor().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Warning {
+    return new Warning().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Processor {
-    return new Processor().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Warning {
+    return new Warning().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Processor {
-    return new Processor().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Warning {
+    return new Warning().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Processor | PlainMessage<Processor> | undefined, b: Processor | PlainMessage<Processor> | undefined): boolean {
-    return proto3.util.equals(Processor, a, b);
+  static equals(a: Warning | PlainMessage<Warning> | undefined, b: Warning | PlainMessage<Warning> | undefined): boolean {
+    return proto3.util.equals(Warning, a, b);
   }
 }
 
 /**
- * @generated from message proto.api.v1.Task
+ * @generated from message proto.api.v1.File
  */
-export class Task extends Message<Task> {
-  /**
-   * @generated from field: string id = 1;
-   */
-  id = "";
-
-  /**
-   * @generated from field: string show_name = 2;
-   */
-  showName = "";
-
-  /**
-   * @generated from field: string description = 3;
-   */
-  description = "";
-
+export class File extends Message<File> {
   /**
-   * @generated from field: string kind = 4;
+   * Relative slash-separated path in the home directory. Parent directories are created.
+   *
+   * @generated from field: string path = 1;
    */
-  kind = "";
+  path = "";
 
   /**
-   * @generated from field: proto.api.v1.PhasedTask compile = 5;
+   * @generated from field: bytes content = 2;
    */
-  compile?: PhasedTask;
+  content = new Uint8Array(0);
 
   /**
-   * @generated from field: proto.api.v1.PhasedTask run = 6;
+   * The file is written with mode 0755 instead of 0644.
+   *
+   * @generated from field: bool executable = 3;
    */
-  run?: PhasedTask;
+  executable = false;
 
-  constructor(data?: PartialMessage<Task>) {
+  constructor(data?: PartialMessage<File>) {
     super();
     proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
-  static readonly typeName = "proto.api.v1.Task";
+  static readonly typeName = "proto.api.v1.File";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
-    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 4, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
-    { no: 5, name: "compile", kind: "message", T: PhasedTask },
-    { no: 6, name: "run", kind: "message", T: PhasedTask },
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "content", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 3, name: "executable", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
-  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Task {
-    return new Task().fromBinary(bytes, options);
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): File {
+    return new File().fromBinary(bytes, options);
   }
 
-  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Task {
-    return new Task().fromJson(jsonValue, options);
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): File {
+    return new File().fromJson(jsonValue, options);
   }
 
-  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Task {
-    return new Task().fromJsonString(jsonString, options);
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): File {
+    return new File().fromJsonString(jsonString, options);
   }
 
-  static equals(a: Task | PlainMessage<Task> | undefined, b: Task | PlainMessage<Task> | undefined): boolean {
-    return proto3.util.equals(Task, a, b);
+  static equals(a: File | PlainMessage<File> | undefined, b: File | PlainMessage<File> | undefined): boolean {
+    return proto3.util.equals(File, a, b);
   }
 }
 
 /**
- * @generated from message proto.api.v1.PhasedTask
+ * Archive of files. Only regular files and directories are allowed.
+ *
+ * @generated from message proto.api.v1.Archive
  */
-export class PhasedTask extends Message<PhasedTask> {
-  constructor(data?: PartialMessage<PhasedTask>) {
-    super();
-    proto3.util.initPartial(data, this);
+export class Archive extends Message<Archive> {
+  /**
+   * @generated from field: proto.api.v1.Archive.Format format = 1;
+   */
+  format = Archive_Format.UNSPECIFIED;
+
+  /**
+   * @generated from field: bytes content = 2;
+   */
+  content = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<Archive>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Archive";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "format", kind: "enum", T: proto3.getEnumType(Archive_Format) },
+    { no: 2, name: "content", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Archive {
+    return new Archive().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Archive {
+    return new Archive().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Archive {
+    return new Archive().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Archive | PlainMessage<Archive> | undefined, b: Archive | PlainMessage<Archive> | undefined): boolean {
+    return proto3.util.equals(Archive, a, b);
+  }
+}
+
+/**
+ * @generated from enum proto.api.v1.Archive.Format
+ */
+export enum Archive_Format {
+  /**
+   * detected from the content
+   *
+   * @generated from enum value: FORMAT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: FORMAT_TAR = 1;
+   */
+  TAR = 1,
+
+  /**
+   * @generated from enum value: FORMAT_TAR_GZIP = 2;
+   */
+  TAR_GZIP = 2,
+
+  /**
+   * @generated from enum value: FORMAT_ZIP = 3;
+   */
+  ZIP = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(Archive_Format)
+proto3.util.setEnumType(Archive_Format, "proto.api.v1.Archive.Format", [
+  { no: 0, name: "FORMAT_UNSPECIFIED" },
+  { no: 1, name: "FORMAT_TAR" },
+  { no: 2, name: "FORMAT_TAR_GZIP" },
+  { no: 3, name: "FORMAT_ZIP" },
+]);
+
+/**
+ * Artifact is a file matched by artifacts of the task after phases.
+ * Small ones have content, and large ones have a handle for DownloadArtifact.
+ *
+ * @generated from message proto.api.v1.Artifact
+ */
+export class Artifact extends Message<Artifact> {
+  /**
+   * @generated from field: string path = 1;
+   */
+  path = "";
+
+  /**
+   * bytes
+   *
+   * @generated from field: int64 size = 2;
+   */
+  size = protoInt64.zero;
+
+  /**
+   * @generated from field: bytes content = 3;
+   */
+  content = new Uint8Array(0);
+
+  /**
+   * @generated from field: string handle = 4;
+   */
+  handle = "";
+
+  /**
+   * set if neither content nor handle is returned. e.g. too large
+   *
+   * @generated from field: string omitted_reason = 5;
+   */
+  omittedReason = "";
+
+  constructor(data?: PartialMessage<Artifact>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Artifact";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "size", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "content", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+    { no: 4, name: "handle", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "omitted_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Artifact {
+    return new Artifact().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Artifact {
+    return new Artifact().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Artifact {
+    return new Artifact().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Artifact | PlainMessage<Artifact> | undefined, b: Artifact | PlainMessage<Artifact> | undefined): boolean {
+    return proto3.util.equals(Artifact, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.DownloadArtifactRequest
+ */
+export class DownloadArtifactRequest extends Message<DownloadArtifactRequest> {
+  /**
+   * @generated from field: string handle = 1;
+   */
+  handle = "";
+
+  constructor(data?: PartialMessage<DownloadArtifactRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.DownloadArtifactRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "handle", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DownloadArtifactRequest {
+    return new DownloadArtifactRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DownloadArtifactRequest {
+    return new DownloadArtifactRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DownloadArtifactRequest {
+    return new DownloadArtifactRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DownloadArtifactRequest | PlainMessage<DownloadArtifactRequest> | undefined, b: DownloadArtifactRequest | PlainMessage<DownloadArtifactRequest> | undefined): boolean {
+    return proto3.util.equals(DownloadArtifactRequest, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.DownloadArtifactResponse
+ */
+export class DownloadArtifactResponse extends Message<DownloadArtifactResponse> {
+  /**
+   * @generated from field: bytes chunk = 1;
+   */
+  chunk = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<DownloadArtifactResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.DownloadArtifactResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "chunk", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DownloadArtifactResponse {
+    return new DownloadArtifactResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DownloadArtifactResponse {
+    return new DownloadArtifactResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DownloadArtifactResponse {
+    return new DownloadArtifactResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DownloadArtifactResponse | PlainMessage<DownloadArtifactResponse> | undefined, b: DownloadArtifactResponse | PlainMessage<DownloadArtifactResponse> | undefined): boolean {
+    return proto3.util.equals(DownloadArtifactResponse, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.Output
+ */
+export class Output extends Message<Output> {
+  /**
+   * 0 = stdout, 1 = stderr
+   *
+   * @generated from field: int64 kind = 1;
+   */
+  kind = protoInt64.zero;
+
+  /**
+   * utf8
+   *
+   * @generated from field: bytes buffer = 2;
+   */
+  buffer = new Uint8Array(0);
+
+  constructor(data?: PartialMessage<Output>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Output";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "kind", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "buffer", kind: "scalar", T: 12 /* ScalarType.BYTES */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Output {
+    return new Output().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Output {
+    return new Output().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Output {
+    return new Output().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Output | PlainMessage<Output> | undefined, b: Output | PlainMessage<Output> | undefined): boolean {
+    return proto3.util.equals(Output, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.Result
+ */
+export class Result extends Message<Result> {
+  /**
+   * @generated from field: int64 exit_code = 1;
+   */
+  exitCode = protoInt64.zero;
+
+  /**
+   * "" | "cpu_time_exceeded" | "wall_time_exceeded" | "memory_limit_exceeded" | "signaled"
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason = "";
+
+  /**
+   * set if reason is "signaled"
+   *
+   * @generated from field: int64 signal = 3;
+   */
+  signal = protoInt64.zero;
+
+  /**
+   * bytes, set if reason is "memory_limit_exceeded"
+   *
+   * @generated from field: int64 memory_limit = 4;
+   */
+  memoryLimit = protoInt64.zero;
+
+  /**
+   * @generated from field: string image_digest = 5;
+   */
+  imageDigest = "";
+
+  /**
+   * the compile phase is skipped and its outputs are replayed from the build cache
+   *
+   * @generated from field: bool cached = 6;
+   */
+  cached = false;
+
+  constructor(data?: PartialMessage<Result>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Result";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "exit_code", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "signal", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "memory_limit", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "image_digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "cached", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Result {
+    return new Result().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Result {
+    return new Result().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Result {
+    return new Result().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Result | PlainMessage<Result> | undefined, b: Result | PlainMessage<Result> | undefined): boolean {
+    return proto3.util.equals(Result, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.Language
+ */
+export class Language extends Message<Language> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.Processor processors = 3;
+   */
+  processors: Processor[] = [];
+
+  /**
+   * @generated from field: string description = 4;
+   */
+  description = "";
+
+  /**
+   * e.g. ".py"
+   *
+   * @generated from field: repeated string file_extensions = 5;
+   */
+  fileExtensions: string[] = [];
+
+  /**
+   * e.g. "py"
+   *
+   * @generated from field: repeated string aliases = 6;
+   */
+  aliases: string[] = [];
+
+  /**
+   * e.g. "python3"
+   *
+   * @generated from field: repeated string shebangs = 8;
+   */
+  shebangs: string[] = [];
+
+  /**
+   * @generated from field: string default_processor_id = 7;
+   */
+  defaultProcessorId = "";
+
+  constructor(data?: PartialMessage<Language>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Language";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "processors", kind: "message", T: Processor, repeated: true },
+    { no: 4, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "file_extensions", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "aliases", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 8, name: "shebangs", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "default_processor_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Language {
+    return new Language().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Language {
+    return new Language().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Language {
+    return new Language().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Language | PlainMessage<Language> | undefined, b: Language | PlainMessage<Language> | undefined): boolean {
+    return proto3.util.equals(Language, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.Processor
+ */
+export class Processor extends Message<Processor> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string default_filename = 4;
+   */
+  defaultFilename = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.Task tasks = 5;
+   */
+  tasks: Task[] = [];
+
+  /**
+   * @generated from field: bool disabled = 6;
+   */
+  disabled = false;
+
+  /**
+   * @generated from field: string disabled_reason = 7;
+   */
+  disabledReason = "";
+
+  /**
+   * @generated from field: string image_digest = 8;
+   */
+  imageDigest = "";
+
+  /**
+   * @generated from field: string docker_image = 9;
+   */
+  dockerImage = "";
+
+  /**
+   * @generated from field: string version = 10;
+   */
+  version = "";
+
+  /**
+   * @generated from field: string sample_code = 11;
+   */
+  sampleCode = "";
+
+  /**
+   * detected_version is the output of the version command in the image
+   *
+   * @generated from field: string detected_version = 12;
+   */
+  detectedVersion = "";
+
+  /**
+   * @generated from field: proto.api.v1.Lifecycle lifecycle = 13;
+   */
+  lifecycle?: Lifecycle;
+
+  /**
+   * @generated from field: string default_task_id = 14;
+   */
+  defaultTaskId = "";
+
+  constructor(data?: PartialMessage<Processor>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Processor";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "default_filename", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "tasks", kind: "message", T: Task, repeated: true },
+    { no: 6, name: "disabled", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "disabled_reason", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "image_digest", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "docker_image", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "sample_code", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 12, name: "detected_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 13, name: "lifecycle", kind: "message", T: Lifecycle },
+    { no: 14, name: "default_task_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Processor {
+    return new Processor().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Processor {
+    return new Processor().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Processor {
+    return new Processor().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Processor | PlainMessage<Processor> | undefined, b: Processor | PlainMessage<Processor> | undefined): boolean {
+    return proto3.util.equals(Processor, a, b);
+  }
+}
+
+/**
+ * Lifecycle is the status of processors and tasks.
+ *
+ * @generated from message proto.api.v1.Lifecycle
+ */
+export class Lifecycle extends Message<Lifecycle> {
+  /**
+   * "active", "deprecated" or "disabled"
+   *
+   * @generated from field: string status = 1;
+   */
+  status = "";
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message = "";
+
+  /**
+   * ID of the processor or the task to use instead
+   *
+   * @generated from field: string replaced_by = 3;
+   */
+  replacedBy = "";
+
+  constructor(data?: PartialMessage<Lifecycle>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Lifecycle";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "message", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "replaced_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Lifecycle {
+    return new Lifecycle().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Lifecycle {
+    return new Lifecycle().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Lifecycle {
+    return new Lifecycle().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Lifecycle | PlainMessage<Lifecycle> | undefined, b: Lifecycle | PlainMessage<Lifecycle> | undefined): boolean {
+    return proto3.util.equals(Lifecycle, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.Task
+ */
+export class Task extends Message<Task> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description = "";
+
+  /**
+   * @generated from field: string kind = 4;
+   */
+  kind = "";
+
+  /**
+   * @generated from field: proto.api.v1.PhasedTask compile = 5;
+   */
+  compile?: PhasedTask;
+
+  /**
+   * @generated from field: proto.api.v1.PhasedTask run = 6;
+   */
+  run?: PhasedTask;
+
+  /**
+   * @generated from field: repeated proto.api.v1.OptionGroup option_groups = 7;
+   */
+  optionGroups: OptionGroup[] = [];
+
+  /**
+   * @generated from field: bool allow_run_args = 8;
+   */
+  allowRunArgs = false;
+
+  /**
+   * globs of files returned after phases
+   *
+   * @generated from field: repeated string artifacts = 10;
+   */
+  artifacts: string[] = [];
+
+  /**
+   * @generated from field: proto.api.v1.Lifecycle lifecycle = 9;
+   */
+  lifecycle?: Lifecycle;
+
+  constructor(data?: PartialMessage<Task>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Task";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "description", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "kind", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "compile", kind: "message", T: PhasedTask },
+    { no: 6, name: "run", kind: "message", T: PhasedTask },
+    { no: 7, name: "option_groups", kind: "message", T: OptionGroup, repeated: true },
+    { no: 8, name: "allow_run_args", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 10, name: "artifacts", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 9, name: "lifecycle", kind: "message", T: Lifecycle },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Task {
+    return new Task().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Task {
+    return new Task().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Task {
+    return new Task().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Task | PlainMessage<Task> | undefined, b: Task | PlainMessage<Task> | undefined): boolean {
+    return proto3.util.equals(Task, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.OptionGroup
+ */
+export class OptionGroup extends Message<OptionGroup> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated proto.api.v1.OptionChoice choices = 3;
+   */
+  choices: OptionChoice[] = [];
+
+  /**
+   * @generated from field: repeated string default_choice_ids = 4;
+   */
+  defaultChoiceIds: string[] = [];
+
+  /**
+   * @generated from field: bool multiple = 5;
+   */
+  multiple = false;
+
+  /**
+   * @generated from field: bool free_form = 6;
+   */
+  freeForm = false;
+
+  /**
+   * patterns which free-form options must fully match
+   *
+   * @generated from field: repeated string safelist = 7;
+   */
+  safelist: string[] = [];
+
+  constructor(data?: PartialMessage<OptionGroup>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.OptionGroup";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "choices", kind: "message", T: OptionChoice, repeated: true },
+    { no: 4, name: "default_choice_ids", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "multiple", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 6, name: "free_form", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 7, name: "safelist", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OptionGroup {
+    return new OptionGroup().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OptionGroup {
+    return new OptionGroup().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OptionGroup {
+    return new OptionGroup().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OptionGroup | PlainMessage<OptionGroup> | undefined, b: OptionGroup | PlainMessage<OptionGroup> | undefined): boolean {
+    return proto3.util.equals(OptionGroup, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.OptionChoice
+ */
+export class OptionChoice extends Message<OptionChoice> {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * @generated from field: string show_name = 2;
+   */
+  showName = "";
+
+  /**
+   * @generated from field: repeated string args = 3;
+   */
+  args: string[] = [];
+
+  constructor(data?: PartialMessage<OptionChoice>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.OptionChoice";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "show_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "args", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): OptionChoice {
+    return new OptionChoice().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): OptionChoice {
+    return new OptionChoice().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): OptionChoice {
+    return new OptionChoice().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: OptionChoice | PlainMessage<OptionChoice> | undefined, b: OptionChoice | PlainMessage<OptionChoice> | undefined): boolean {
+    return proto3.util.equals(OptionChoice, a, b);
+  }
+}
+
+/**
+ * @generated from message proto.api.v1.PhasedTask
+ */
+export class PhasedTask extends Message<PhasedTask> {
+  /**
+   * empty if redacted
+   *
+   * @generated from field: repeated string cmd = 1;
+   */
+  cmd: string[] = [];
+
+  /**
+   * @generated from field: bool shell = 2;
+   */
+  shell = false;
+
+  /**
+   * @generated from field: bool cmd_redacted = 3;
+   */
+  cmdRedacted = false;
+
+  /**
+   * effective limits
+   *
+   * @generated from field: proto.api.v1.Limits limits = 4;
+   */
+  limits?: Limits;
+
+  constructor(data?: PartialMessage<PhasedTask>) {
+    super();
+    proto3.util.initPartial(data, this);
   }
 
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "proto.api.v1.PhasedTask";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cmd", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 2, name: "shell", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "cmd_redacted", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "limits", kind: "message", T: Limits },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PhasedTask {
@@ -445,3 +1411,88 @@ export class PhasedTask extends Message<PhasedTask> {
   }
 }
 
+/**
+ * @generated from message proto.api.v1.Limits
+ */
+export class Limits extends Message<Limits> {
+  /**
+   * sec
+   *
+   * @generated from field: int64 cpu_time = 1;
+   */
+  cpuTime = protoInt64.zero;
+
+  /**
+   * sec
+   *
+   * @generated from field: int64 wall_time = 2;
+   */
+  wallTime = protoInt64.zero;
+
+  /**
+   * bytes
+   *
+   * @generated from field: int64 memory = 3;
+   */
+  memory = protoInt64.zero;
+
+  /**
+   * bytes, memory + swap
+   *
+   * @generated from field: int64 memory_swap = 4;
+   */
+  memorySwap = protoInt64.zero;
+
+  /**
+   * microsec per cpu_period
+   *
+   * @generated from field: int64 cpu_quota = 5;
+   */
+  cpuQuota = protoInt64.zero;
+
+  /**
+   * microsec
+   *
+   * @generated from field: int64 cpu_period = 6;
+   */
+  cpuPeriod = protoInt64.zero;
+
+  /**
+   * @generated from field: int64 cores = 7;
+   */
+  cores = protoInt64.zero;
+
+  constructor(data?: PartialMessage<Limits>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "proto.api.v1.Limits";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cpu_time", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "wall_time", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "memory", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 4, name: "memory_swap", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 5, name: "cpu_quota", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 6, name: "cpu_period", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 7, name: "cores", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Limits {
+    return new Limits().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Limits {
+    return new Limits().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Limits {
+    return new Limits().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Limits | PlainMessage<Limits> | undefined, b: Limits | PlainMessage<Limits> | undefined): boolean {
+    return proto3.util.equals(Limits, a, b);
+  }
+}
+
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MatchArtifact reports whether the file is an artifact of the task. The path is slash-separated and relative to the home directory.
+func (t *Task) MatchArtifact(name string) bool {
+	for _, pattern := range t.Artifacts {
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches components by path.Match. "**" matches zero or more components.
+func matchGlob(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchGlob(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], name[0]); !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], name[1:])
+}
+
+func validateGlob(pattern string) error {
+	if pattern == "" {
+		return errors.New("must not be empty")
+	}
+	if strings.HasPrefix(pattern, "/") {
+		return errors.Newf("must be relative: '%s'", pattern)
+	}
+	for _, c := range strings.Split(pattern, "/") {
+		switch c {
+		case "", ".", "..":
+			return errors.Newf("must be clean: '%s'", pattern)
+		}
+		if _, err := path.Match(c, ""); err != nil {
+			return errors.Wrapf(err, "invalid glob '%s'", pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+)
+
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "+"
+	ChangeRemoved ChangeKind = "-"
+	ChangeChanged ChangeKind = "~"
+)
+
+// Change is a difference between profiles. Old and New are JSON values of changed fields.
+type Change struct {
+	Kind ChangeKind
+	Path string // e.g. "languages[cpp].processors[gcc].docker_image", "templates[gcc-base].tasks[run]"
+	Old  string
+	New  string
+}
+
+func (c *Change) String() string {
+	if c.Kind == ChangeChanged {
+		return fmt.Sprintf("%s %s: %s -> %s", c.Kind, c.Path, c.Old, c.New)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, c.Path)
+}
+
+// Diff returns changes from a to b. Languages, templates, processors and tasks are matched by IDs.
+func Diff(a, b *Profile) ([]Change, error) {
+	d := &differ{}
+
+	for _, la := range a.Languages {
+		langPath := fmt.Sprintf("languages[%s]", la.ID)
+		lb, err := b.Language(la.ID)
+		if err != nil {
+			d.add(ChangeRemoved, langPath)
+			continue
+		}
+		d.fields(langPath, la, *lb, "processors")
+		d.processors(langPath+".processors", la.Processors, lb.Processors)
+	}
+	for _, lb := range b.Languages {
+		if _, err := a.Language(lb.ID); err != nil {
+			d.add(ChangeAdded, fmt.Sprintf("languages[%s]", lb.ID))
+		}
+	}
+	d.processors("templates", a.Templates, b.Templates)
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.changes, nil
+}
+
+// differ accumulates changes. Once a comparison fails, later ones are skipped.
+type differ struct {
+	changes []Change
+	err     error
+}
+
+func (d *differ) add(kind ChangeKind, path string) {
+	d.changes = append(d.changes, Change{Kind: kind, Path: path})
+}
+
+func (d *differ) processors(path string, as, bs []Processor) {
+	for _, pa := range as {
+		procPath := fmt.Sprintf("%s[%s]", path, pa.ID)
+		pb := findProcessor(bs, pa.ID)
+		if pb == nil {
+			d.add(ChangeRemoved, procPath)
+			continue
+		}
+		d.fields(procPath, pa, *pb, "tasks")
+
+		for _, ta := range pa.Tasks {
+			taskPath := fmt.Sprintf("%s.tasks[%s]", procPath, ta.ID)
+			tb, err := pb.Task(ta.ID)
+			if err != nil {
+				d.add(ChangeRemoved, taskPath)
+				continue
+			}
+			d.fields(taskPath, ta, *tb, "")
+		}
+		for _, tb := range pb.Tasks {
+			if _, err := pa.Task(tb.ID); err != nil {
+				d.add(ChangeAdded, fmt.Sprintf("%s.tasks[%s]", procPath, tb.ID))
+			}
+		}
+	}
+	for _, pb := range bs {
+		if findProcessor(as, pb.ID) == nil {
+			d.add(ChangeAdded, fmt.Sprintf("%s[%s]", path, pb.ID))
+		}
+	}
+}
+
+func findProcessor(procs []Processor, id string) *Processor {
+	for i := range procs {
+		if procs[i].ID == id {
+			return &procs[i]
+		}
+	}
+	return nil
+}
+
+// fields compares fields of a and b by their JSON forms, except the nested field compared separately.
+func (d *differ) fields(path string, a, b interface{}, nested string) {
+	if d.err != nil {
+		return
+	}
+	fa, err := jsonFields(a)
+	if err != nil {
+		d.err = errors.Wrapf(err, "failed to compare %s", path)
+		return
+	}
+	fb, err := jsonFields(b)
+	if err != nil {
+		d.err = errors.Wrapf(err, "failed to compare %s", path)
+		return
+	}
+
+	keys := make(map[string]bool)
+	for k := range fa {
+		keys[k] = true
+	}
+	for k := range fb {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		if k != nested {
+			sorted = append(sorted, k)
+		}
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		va, vb := fa[k], fb[k]
+		if string(va) == string(vb) {
+			continue
+		}
+		d.changes = append(d.changes, Change{
+			Kind: ChangeChanged,
+			Path: path + "." + k,
+			Old:  jsonOrNone(va),
+			New:  jsonOrNone(vb),
+		})
+	}
+}
+
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func jsonOrNone(v json.RawMessage) string {
+	if v == nil {
+		return "(none)"
+	}
+	return string(v)
+}
@@ -1,36 +1,237 @@
 package domain
 
+import (
+	"regexp"
+	"strings"
+)
+
 type Profile struct {
-	Languages []Language `json:"languages"`
+	Languages []Language `json:"languages" yaml:"languages"`
+
+	// Templates are base processors which processors can extend. They are not served by themselves.
+	Templates []Processor `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// DockerImages returns the distinct images referenced in the profile.
+func (p *Profile) DockerImages() []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, l := range p.Languages {
+		for _, proc := range l.Processors {
+			image := proc.ImageRef()
+			if seen[image] {
+				continue
+			}
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	return images
 }
 
 type Language struct {
-	ID         string      `json:"id"`
-	ShowName   string      `json:"show_name"`
-	Processors []Processor `json:"processors"`
+	ID         string      `json:"id" yaml:"id"`
+	ShowName   string      `json:"show_name" yaml:"show_name"`
+	Processors []Processor `json:"processors" yaml:"processors"`
+
+	Description    string   `json:"description,omitempty" yaml:"description,omitempty"`
+	FileExtensions []string `json:"file_extensions,omitempty" yaml:"file_extensions,omitempty"` // e.g. ".py"
+	// Shebangs are interpreter names in shebangs to detect the language. e.g. ["python3", "python"]
+	Shebangs []string `json:"shebangs,omitempty" yaml:"shebangs,omitempty"`
+
+	// Aliases are other names accepted as the language ID. e.g. ["py", "python3"]
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// DefaultProcessor is the ID of the processor used if not specified. If empty, the first active one is used.
+	DefaultProcessor string `json:"default_processor,omitempty" yaml:"default_processor,omitempty"`
+
+	// Disabled hides the language from users without removing it.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
 }
 
 type Processor struct {
-	ID       string `json:"id"`
-	ShowName string `json:"show_name"`
+	ID          string `json:"id" yaml:"id"`
+	ShowName    string `json:"show_name" yaml:"show_name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version,omitempty" yaml:"version,omitempty"` // e.g. "13.2.0"
+	// VersionCmd is run once per image to detect the actual version. e.g. ["gcc", "--version"]
+	VersionCmd []string `json:"version_cmd,omitempty" yaml:"version_cmd,omitempty"`
+
+	DockerImage string `json:"docker_image" yaml:"docker_image"`
+	// DockerImageDigest pins DockerImage to the content. e.g. "sha256:..."
+	DockerImageDigest string `json:"docker_image_digest,omitempty" yaml:"docker_image_digest,omitempty"`
+
+	DefaultFilename string `json:"default_filename" yaml:"default_filename"`
+	// OutputFilename is the path of the output binary. If empty, DefaultFilename without the extension is used.
+	OutputFilename string `json:"output_filename,omitempty" yaml:"output_filename,omitempty"`
+	// SampleCode is the initial content of DefaultFilename for editors.
+	SampleCode string `json:"sample_code,omitempty" yaml:"sample_code,omitempty"`
+
+	Tasks []Task `json:"tasks" yaml:"tasks"`
+	// DefaultTask is the ID of the task used if not specified. If empty, the first active action is used.
+	DefaultTask string `json:"default_task,omitempty" yaml:"default_task,omitempty"`
+
+	// Extends is the ID of a processor in the same language or a template to inherit from.
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// Vars are substituted into fields like "{{.Version}}" at load time.
+	// ID and SourceFile (= DefaultFilename) are also available.
+	Vars map[string]string `json:"vars,omitempty" yaml:"vars,omitempty"`
 
-	DockerImage string `json:"docker_image"`
+	// Lifecycle retires the processor gracefully. It is not inherited.
+	Lifecycle `yaml:",inline"`
+}
+
+const (
+	StatusActive     = "active"
+	StatusDeprecated = "deprecated" // still runnable, but users are warned
+	StatusDisabled   = "disabled"   // listed, but not runnable
+)
+
+var Statuses = []string{StatusActive, StatusDeprecated, StatusDisabled}
 
-	DefaultFilename string `json:"default_filename"`
+// Lifecycle is the status of processors and tasks.
+type Lifecycle struct {
+	Status string `json:"status,omitempty" yaml:"status,omitempty"` // "active" if empty
+	// StatusMessage tells users why it is deprecated or disabled.
+	StatusMessage string `json:"status_message,omitempty" yaml:"status_message,omitempty"`
+	// ReplacedBy is the ID of the sibling processor or task to use instead.
+	ReplacedBy string `json:"replaced_by,omitempty" yaml:"replaced_by,omitempty"`
+}
+
+func (s *Lifecycle) IsDeprecated() bool {
+	return s.Status == StatusDeprecated
+}
 
-	Tasks []Task `json:"tasks"`
+func (s *Lifecycle) IsDisabled() bool {
+	return s.Status == StatusDisabled
+}
+
+// ImageRef returns the image reference to run. If the digest is pinned, the tag is replaced with it.
+func (p *Processor) ImageRef() string {
+	if p.DockerImageDigest == "" || strings.Contains(p.DockerImage, "@") {
+		return p.DockerImage
+	}
+
+	name := p.DockerImage
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i] // trim tag
+	}
+	return name + "@" + p.DockerImageDigest
 }
 
 type Task struct {
-	ID       string `json:"id"`
-	ShowName string `json:"show_name"`
+	ID          string `json:"id" yaml:"id"`
+	ShowName    string `json:"show_name" yaml:"show_name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	Kind string `json:"kind" yaml:"kind"` // "action" | "tool"
+
+	Compile *PhasedTask `json:"compile,omitempty" yaml:"compile,omitempty"`
+	Run     *PhasedTask `json:"run,omitempty" yaml:"run,omitempty"`
 
-	Kind string `json:"kind"` // "action" | "tool"
+	// OptionGroups are options users can choose. Chosen options are expanded to {options}.
+	OptionGroups []OptionGroup `json:"option_groups,omitempty" yaml:"option_groups,omitempty"`
+	// AllowRunArgs allows users to pass program arguments, which are expanded to {args} or appended to the run command.
+	AllowRunArgs bool `json:"allow_run_args,omitempty" yaml:"allow_run_args,omitempty"`
+	// Artifacts are globs of files returned after phases, relative to the home directory. e.g. "out/*.png", "**/*.csv"
+	Artifacts []string `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
 
-	Compile *PhasedTask `json:"compile,omitempty"`
-	Run     *PhasedTask `json:"run,omitempty"`
+	Lifecycle `yaml:",inline"`
+}
+
+// hasPlaceholder reports whether any command of phases contains the placeholder.
+func (t *Task) hasPlaceholder(placeholder string) bool {
+	for _, phase := range []*PhasedTask{t.Compile, t.Run} {
+		if phase == nil {
+			continue
+		}
+		for _, arg := range phase.Cmd {
+			if strings.Contains(arg, placeholder) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Match reports whether the free-form option fully matches one of the safelist.
+// Patterns are compiled by Expand, so options never match groups of profiles not expanded.
+func (g *OptionGroup) Match(option string) bool {
+	for _, re := range g.compiledSafelist {
+		if re.MatchString(option) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileSafelist compiles patterns of the safelist. Invalid ones are skipped since Validate reports them.
+func (g *OptionGroup) compileSafelist() {
+	g.compiledSafelist = make([]*regexp.Regexp, 0, len(g.Safelist))
+	for _, pattern := range g.Safelist {
+		re, err := compileSafelistPattern(pattern)
+		if err != nil {
+			continue
+		}
+		g.compiledSafelist = append(g.compiledSafelist, re)
+	}
+}
+
+// compileSafelistPattern compiles the pattern to match whole options.
+// The pattern must be valid by itself not to escape the anchors. e.g. "a)|(.*"
+func compileSafelistPattern(pattern string) (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+type OptionGroup struct {
+	ID       string `json:"id" yaml:"id"`
+	ShowName string `json:"show_name" yaml:"show_name"`
+
+	// Choices are enumerated options. Only one can be chosen unless Multiple.
+	Choices  []OptionChoice `json:"choices,omitempty" yaml:"choices,omitempty"`
+	Default  []string       `json:"default,omitempty" yaml:"default,omitempty"` // choice IDs used if not chosen
+	Multiple bool           `json:"multiple,omitempty" yaml:"multiple,omitempty"`
+
+	// FreeForm allows arbitrary options fully matching one of Safelist patterns. e.g. "-W[a-z-]+"
+	FreeForm bool     `json:"free_form,omitempty" yaml:"free_form,omitempty"`
+	Safelist []string `json:"safelist,omitempty" yaml:"safelist,omitempty"`
+
+	compiledSafelist []*regexp.Regexp // set by Expand
+}
+
+type OptionChoice struct {
+	ID       string   `json:"id" yaml:"id"`
+	ShowName string   `json:"show_name" yaml:"show_name"`
+	Args     []string `json:"args" yaml:"args"` // e.g. ["-O2"]
 }
 
 type PhasedTask struct {
-	Cmd []string `json:"cmd"`
+	// Cmd is the argument vector executed directly without shells (exec form).
+	// Placeholders {source}, {sources}, {output}, {options} and {args} are expanded per request.
+	Cmd []string `json:"cmd" yaml:"cmd"`
+	// Shell runs Cmd joined by spaces via "/bin/sh -c" (shell form). The image must have /bin/sh.
+	// Arguments are kept as written to use operators like "&&", and only values of placeholders are quoted.
+	Shell bool `json:"shell,omitempty" yaml:"shell,omitempty"`
+
+	// Env is fixed environment variables of the phase. e.g. GOCACHE
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+	// AllowedEnv is names of environment variables which users can set by requests.
+	AllowedEnv []string `json:"allowed_env,omitempty" yaml:"allowed_env,omitempty"`
+
+	Limits *Limits `json:"limits,omitempty" yaml:"limits,omitempty"`
+}
+
+// Limits overrides the default resource limits of a phase. Zero values mean defaults.
+type Limits struct {
+	CPUTime  int64 `json:"cpu_time,omitempty" yaml:"cpu_time,omitempty"`   // sec
+	WallTime int64 `json:"wall_time,omitempty" yaml:"wall_time,omitempty"` // sec
+
+	Memory     int64 `json:"memory,omitempty" yaml:"memory,omitempty"`           // bytes
+	MemorySwap int64 `json:"memory_swap,omitempty" yaml:"memory_swap,omitempty"` // bytes, memory + swap
+
+	CPUQuota  int64 `json:"cpu_quota,omitempty" yaml:"cpu_quota,omitempty"`   // microsec per CPUPeriod
+	CPUPeriod int64 `json:"cpu_period,omitempty" yaml:"cpu_period,omitempty"` // microsec
+	Cores     int   `json:"cores,omitempty" yaml:"cores,omitempty"`           // number of dedicated cores
 }
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+)
+
+// Kinds of entities in NotFoundError
+const (
+	KindLanguage  = "language"
+	KindProcessor = "processor"
+	KindTask      = "task"
+)
+
+// NotFoundError tells which entity is not found. It matches ErrNotFound by errors.Is.
+type NotFoundError struct {
+	Kind   string
+	ID     string // empty if a default or a detected one is not found
+	Parent string // e.g. "language 'cpp'"
+}
+
+func (e *NotFoundError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("no %s found for %s", e.Kind, e.Parent)
+	}
+	if e.Parent == "" {
+		return fmt.Sprintf("%s '%s' not found", e.Kind, e.ID)
+	}
+	return fmt.Sprintf("%s '%s' not found in %s", e.Kind, e.ID, e.Parent)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Language returns the language by the ID.
+func (p *Profile) Language(id string) (*Language, error) {
+	for i := range p.Languages {
+		if p.Languages[i].ID == id {
+			return &p.Languages[i], nil
+		}
+	}
+	return nil, &NotFoundError{Kind: KindLanguage, ID: id}
+}
+
+func (p *Profile) AddLanguage(l Language) error {
+	if _, err := p.Language(l.ID); err == nil {
+		return errors.Wrapf(ErrAlreadyExists, "language '%s'", l.ID)
+	}
+	p.Languages = append(p.Languages, l)
+	return nil
+}
+
+func (p *Profile) RemoveLanguage(id string) error {
+	for i := range p.Languages {
+		if p.Languages[i].ID == id {
+			p.Languages = append(p.Languages[:i], p.Languages[i+1:]...)
+			return nil
+		}
+	}
+	return &NotFoundError{Kind: KindLanguage, ID: id}
+}
+
+// Processor returns the processor by the ID.
+func (l *Language) Processor(id string) (*Processor, error) {
+	for i := range l.Processors {
+		if l.Processors[i].ID == id {
+			return &l.Processors[i], nil
+		}
+	}
+	return nil, &NotFoundError{Kind: KindProcessor, ID: id, Parent: fmt.Sprintf("language '%s'", l.ID)}
+}
+
+func (l *Language) AddProcessor(proc Processor) error {
+	if _, err := l.Processor(proc.ID); err == nil {
+		return errors.Wrapf(ErrAlreadyExists, "processor '%s' in language '%s'", proc.ID, l.ID)
+	}
+	l.Processors = append(l.Processors, proc)
+	return nil
+}
+
+func (l *Language) RemoveProcessor(id string) error {
+	for i := range l.Processors {
+		if l.Processors[i].ID == id {
+			l.Processors = append(l.Processors[:i], l.Processors[i+1:]...)
+			return nil
+		}
+	}
+	return &NotFoundError{Kind: KindProcessor, ID: id, Parent: fmt.Sprintf("language '%s'", l.ID)}
+}
+
+// Task returns the task by the ID.
+func (p *Processor) Task(id string) (*Task, error) {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == id {
+			return &p.Tasks[i], nil
+		}
+	}
+	return nil, &NotFoundError{Kind: KindTask, ID: id, Parent: fmt.Sprintf("processor '%s'", p.ID)}
+}
+
+func (p *Processor) AddTask(t Task) error {
+	if _, err := p.Task(t.ID); err == nil {
+		return errors.Wrapf(ErrAlreadyExists, "task '%s' in processor '%s'", t.ID, p.ID)
+	}
+	p.Tasks = append(p.Tasks, t)
+	return nil
+}
+
+func (p *Processor) RemoveTask(id string) error {
+	for i := range p.Tasks {
+		if p.Tasks[i].ID == id {
+			p.Tasks = append(p.Tasks[:i], p.Tasks[i+1:]...)
+			return nil
+		}
+	}
+	return &NotFoundError{Kind: KindTask, ID: id, Parent: fmt.Sprintf("processor '%s'", p.ID)}
+}
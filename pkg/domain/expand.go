@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Expand returns a new profile whose processors are merged with their bases and whose variables are substituted.
+// The receiver is not modified so that the profile can be saved back as it was written.
+func (p *Profile) Expand() (*Profile, error) {
+	e := &expander{
+		profile: p,
+	}
+
+	templateIDs := make(map[string]int)
+	for i := range p.Templates {
+		e.id(fmt.Sprintf("$.templates[%d]", i), p.Templates[i].ID, templateIDs, i)
+	}
+
+	expanded := &Profile{
+		Languages: make([]Language, 0, len(p.Languages)),
+	}
+	for i, lang := range p.Languages {
+		langPath := fmt.Sprintf("$.languages[%d]", i)
+
+		l := lang
+		l.Processors = make([]Processor, 0, len(lang.Processors))
+		for j := range lang.Processors {
+			procPath := fmt.Sprintf("%s.processors[%d]", langPath, j)
+
+			proc, ok := e.resolve(procPath, i, &lang.Processors[j], nil)
+			if !ok {
+				continue
+			}
+			e.substitute(procPath, proc)
+			for k := range proc.Tasks {
+				for g := range proc.Tasks[k].OptionGroups {
+					proc.Tasks[k].OptionGroups[g].compileSafelist()
+				}
+			}
+			l.Processors = append(l.Processors, *proc)
+		}
+		expanded.Languages = append(expanded.Languages, l)
+	}
+	if len(e.errs) > 0 {
+		return nil, e.errs
+	}
+
+	return expanded, nil
+}
+
+type expander struct {
+	profile *Profile
+	validator
+}
+
+// resolve merges the processor with its bases recursively.
+func (e *expander) resolve(path string, langIndex int, proc *Processor, visiting []string) (*Processor, bool) {
+	if proc.Extends == "" {
+		return proc.clone(), true
+	}
+
+	// Paths are used since a processor can have the same ID as its template
+	for _, p := range visiting {
+		if p == path {
+			e.addf(path+".extends", "cyclic inheritance: %s -> %s", strings.Join(visiting, " -> "), path)
+			return nil, false
+		}
+	}
+	visiting = append(visiting, path)
+
+	basePath, base := e.findBase(langIndex, proc)
+	if base == nil {
+		e.addf(path+".extends", "base processor or template not found: '%s'", proc.Extends)
+		return nil, false
+	}
+
+	merged, ok := e.resolve(basePath, langIndex, base, visiting)
+	if !ok {
+		return nil, false
+	}
+	merged.overlay(proc)
+
+	return merged, true
+}
+
+// findBase finds the base from processors in the same language first, then templates.
+func (e *expander) findBase(langIndex int, proc *Processor) (string, *Processor) {
+	lang := &e.profile.Languages[langIndex]
+	for i := range lang.Processors {
+		base := &lang.Processors[i]
+		if base.ID == proc.Extends && base != proc {
+			return fmt.Sprintf("$.languages[%d].processors[%d]", langIndex, i), base
+		}
+	}
+	for i := range e.profile.Templates {
+		base := &e.profile.Templates[i]
+		if base.ID == proc.Extends {
+			return fmt.Sprintf("$.templates[%d]", i), base
+		}
+	}
+	return "", nil
+}
+
+// substitute expands variables like {{.Version}} in fields of the processor.
+func (e *expander) substitute(path string, proc *Processor) {
+	vars := make(map[string]string, len(proc.Vars)+2)
+	for k, v := range proc.Vars {
+		vars[k] = v
+	}
+	vars["ID"] = proc.ID
+
+	expand := func(fieldPath string, s *string) {
+		if !strings.Contains(*s, "{{") {
+			return
+		}
+		tmpl, err := template.New("value").Option("missingkey=error").Parse(*s)
+		if err != nil {
+			e.addf(fieldPath, "invalid template: %s", err)
+			return
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, vars); err != nil {
+			e.addf(fieldPath, "failed to expand: %s", err)
+			return
+		}
+		*s = sb.String()
+	}
+
+	// SourceFile can be used in other fields
+	expand(path+".default_filename", &proc.DefaultFilename)
+	vars["SourceFile"] = proc.DefaultFilename
+
+	expand(path+".show_name", &proc.ShowName)
+	expand(path+".description", &proc.Description)
+	expand(path+".version", &proc.Version)
+	for i := range proc.VersionCmd {
+		expand(fmt.Sprintf("%s.version_cmd[%d]", path, i), &proc.VersionCmd[i])
+	}
+	expand(path+".docker_image", &proc.DockerImage)
+	expand(path+".output_filename", &proc.OutputFilename)
+	for i := range proc.Tasks {
+		task := &proc.Tasks[i]
+		taskPath := fmt.Sprintf("%s.tasks[%d]", path, i)
+
+		expand(taskPath+".show_name", &task.ShowName)
+		expand(taskPath+".description", &task.Description)
+		if task.Compile != nil {
+			for j := range task.Compile.Cmd {
+				expand(fmt.Sprintf("%s.compile.cmd[%d]", taskPath, j), &task.Compile.Cmd[j])
+			}
+		}
+		if task.Run != nil {
+			for j := range task.Run.Cmd {
+				expand(fmt.Sprintf("%s.run.cmd[%d]", taskPath, j), &task.Run.Cmd[j])
+			}
+		}
+	}
+}
+
+// overlay overwrites fields of the base by non-zero fields of the derived processor.
+// Tasks are merged by IDs, and variables are merged by keys.
+func (p *Processor) overlay(derived *Processor) {
+	p.ID = derived.ID
+	p.Extends = ""
+	p.Lifecycle = derived.Lifecycle
+	if derived.ShowName != "" {
+		p.ShowName = derived.ShowName
+	}
+	if derived.Description != "" {
+		p.Description = derived.Description
+	}
+	if derived.Version != "" {
+		p.Version = derived.Version
+	}
+	if len(derived.VersionCmd) > 0 {
+		p.VersionCmd = append([]string(nil), derived.VersionCmd...)
+	}
+	if derived.DockerImage != "" {
+		p.DockerImage = derived.DockerImage
+		p.DockerImageDigest = "" // The digest of the base is for the other image
+	}
+	if derived.DockerImageDigest != "" {
+		p.DockerImageDigest = derived.DockerImageDigest
+	}
+	if derived.DefaultFilename != "" {
+		p.DefaultFilename = derived.DefaultFilename
+	}
+	if derived.OutputFilename != "" {
+		p.OutputFilename = derived.OutputFilename
+	}
+	if derived.DefaultTask != "" {
+		p.DefaultTask = derived.DefaultTask
+	}
+	if derived.SampleCode != "" {
+		p.SampleCode = derived.SampleCode
+	}
+
+	for k, v := range derived.Vars {
+		if p.Vars == nil {
+			p.Vars = make(map[string]string)
+		}
+		p.Vars[k] = v
+	}
+
+	for _, t := range derived.Tasks {
+		replaced := false
+		for i := range p.Tasks {
+			if p.Tasks[i].ID == t.ID {
+				p.Tasks[i] = *t.clone()
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			p.Tasks = append(p.Tasks, *t.clone())
+		}
+	}
+}
+
+// clone returns a deep copy not to share commands between expanded processors.
+func (p *Processor) clone() *Processor {
+	c := *p
+	c.VersionCmd = append([]string(nil), p.VersionCmd...)
+	if p.Vars != nil {
+		c.Vars = make(map[string]string, len(p.Vars))
+		for k, v := range p.Vars {
+			c.Vars[k] = v
+		}
+	}
+	c.Tasks = make([]Task, 0, len(p.Tasks))
+	for _, t := range p.Tasks {
+		c.Tasks = append(c.Tasks, *t.clone())
+	}
+	return &c
+}
+
+func (t *Task) clone() *Task {
+	c := *t
+	c.Compile = t.Compile.clone()
+	c.Run = t.Run.clone()
+	c.OptionGroups = make([]OptionGroup, 0, len(t.OptionGroups))
+	for _, g := range t.OptionGroups {
+		g.Choices = append([]OptionChoice(nil), g.Choices...)
+		g.Default = append([]string(nil), g.Default...)
+		g.Safelist = append([]string(nil), g.Safelist...)
+		c.OptionGroups = append(c.OptionGroups, g)
+	}
+	c.Artifacts = append([]string(nil), t.Artifacts...)
+	return &c
+}
+
+func (p *PhasedTask) clone() *PhasedTask {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Cmd = append([]string(nil), p.Cmd...)
+	if p.Env != nil {
+		c.Env = make(map[string]string, len(p.Env))
+		for k, v := range p.Env {
+			c.Env[k] = v
+		}
+	}
+	c.AllowedEnv = append([]string(nil), p.AllowedEnv...)
+	if p.Limits != nil {
+		limits := *p.Limits
+		c.Limits = &limits
+	}
+	return &c
+}
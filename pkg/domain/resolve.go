@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// How languages are resolved
+const (
+	MatchedByID            = "id"
+	MatchedByAlias         = "alias"
+	MatchedByShebang       = "shebang"
+	MatchedByFileExtension = "file_extension"
+)
+
+// SourceFile is a file given by users to detect the language.
+type SourceFile struct {
+	Path    string
+	Content []byte
+}
+
+// Resolution is the decision of which language, processor and task to use.
+type Resolution struct {
+	Language  *Language
+	Processor *Processor
+	Task      *Task
+
+	MatchedBy   string // how the language is resolved. e.g. "shebang"
+	MatchedFile string // path of the file the language is detected from
+}
+
+// MatchLanguage returns the language whose ID or alias is the name.
+func (p *Profile) MatchLanguage(name string) (*Language, string, error) {
+	for i := range p.Languages {
+		if p.Languages[i].ID == name {
+			return &p.Languages[i], MatchedByID, nil
+		}
+	}
+	for i := range p.Languages {
+		if contains(p.Languages[i].Aliases, name) {
+			return &p.Languages[i], MatchedByAlias, nil
+		}
+	}
+	return nil, "", &NotFoundError{Kind: KindLanguage, ID: name}
+}
+
+// DetectLanguage detects the language from the files in order. A shebang takes precedence over the file extension.
+// If extensions of multiple languages match, the longest one wins, then the first in the profile.
+func (p *Profile) DetectLanguage(files []SourceFile) (*Language, string, string, error) {
+	for _, f := range files {
+		if interpreter := shebangInterpreter(f.Content); interpreter != "" {
+			for i := range p.Languages {
+				l := &p.Languages[i]
+				if !l.Disabled && contains(l.Shebangs, interpreter) {
+					return l, MatchedByShebang, f.Path, nil
+				}
+			}
+		}
+
+		var found *Language
+		longest := 0
+		for i := range p.Languages {
+			l := &p.Languages[i]
+			if l.Disabled {
+				continue
+			}
+			for _, ext := range l.FileExtensions {
+				if strings.HasSuffix(f.Path, ext) && len(ext) > longest {
+					found, longest = l, len(ext)
+				}
+			}
+		}
+		if found != nil {
+			return found, MatchedByFileExtension, f.Path, nil
+		}
+	}
+
+	return nil, "", "", &NotFoundError{Kind: KindLanguage, Parent: "the files"}
+}
+
+// shebangInterpreter returns the interpreter name in the shebang. e.g. "python3" of "#!/usr/bin/env python3"
+func shebangInterpreter(content []byte) string {
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		return ""
+	}
+	line, _, _ := bufio.NewReader(bytes.NewReader(content[2:])).ReadLine()
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, arg := range fields[1:] {
+			if strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+				continue // options and variables of env
+			}
+			interpreter = path.Base(arg)
+			break
+		}
+	}
+	return interpreter
+}
+
+// Resolve finds the language, the processor and the task.
+// If the language name is empty, it is detected from the files. Empty IDs of the processor and the task select defaults.
+func (p *Profile) Resolve(languageName, processorID, taskID string, files []SourceFile) (*Resolution, error) {
+	r := &Resolution{}
+
+	var err error
+	if languageName == "" {
+		r.Language, r.MatchedBy, r.MatchedFile, err = p.DetectLanguage(files)
+	} else {
+		r.Language, r.MatchedBy, err = p.MatchLanguage(languageName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if processorID == "" {
+		r.Processor, err = r.Language.PreferredProcessor()
+	} else {
+		r.Processor, err = r.Language.Processor(processorID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if taskID == "" {
+		r.Task, err = r.Processor.PreferredTask()
+	} else {
+		r.Task, err = r.Processor.Task(taskID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// PreferredProcessor returns the default processor, or the first active one.
+func (l *Language) PreferredProcessor() (*Processor, error) {
+	if l.DefaultProcessor != "" {
+		return l.Processor(l.DefaultProcessor)
+	}
+	for i := range l.Processors {
+		if !l.Processors[i].IsDeprecated() && !l.Processors[i].IsDisabled() {
+			return &l.Processors[i], nil
+		}
+	}
+	return nil, &NotFoundError{Kind: KindProcessor, Parent: fmt.Sprintf("language '%s'", l.ID)}
+}
+
+// PreferredTask returns the default task, or the first active action.
+func (p *Processor) PreferredTask() (*Task, error) {
+	if p.DefaultTask != "" {
+		return p.Task(p.DefaultTask)
+	}
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		if t.Kind == "action" && !t.IsDeprecated() && !t.IsDisabled() {
+			return t, nil
+		}
+	}
+	return nil, &NotFoundError{Kind: KindTask, Parent: fmt.Sprintf("processor '%s'", p.ID)}
+}
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	_ "embed"
+)
+
+// ProfileJSONSchema is the JSON Schema of Profile for editor support.
+//
+//go:embed profile.schema.json
+var ProfileJSONSchema []byte
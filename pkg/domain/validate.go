@@ -0,0 +1,328 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationError is a problem of the profile located by a JSON path. e.g. "$.languages[0].id"
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors is the list of every problem of the profile.
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	ss := make([]string, 0, len(es))
+	for _, e := range es {
+		ss = append(ss, e.Error())
+	}
+	return fmt.Sprintf("invalid profile (%d problems): %s", len(es), strings.Join(ss, "; "))
+}
+
+var TaskKinds = []string{"action", "tool"}
+
+var digestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// idPattern restricts IDs and aliases since they are used as file names. e.g. "cpp", "gcc-13.2", "c++"
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+
+// IsValidID reports whether the string can be used as an ID or an alias.
+func IsValidID(id string) bool {
+	return idPattern.MatchString(id) && !strings.Contains(id, "..")
+}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidEnvName reports whether the name can be used as an environment variable.
+func IsValidEnvName(name string) bool {
+	return envNamePattern.MatchString(name)
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) addf(path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the profile and reports every problem. It returns nil if the profile is valid.
+func (p *Profile) Validate() error {
+	v := &validator{}
+	v.profile("$", p)
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+func (v *validator) profile(path string, p *Profile) {
+	ids := make(map[string]int)
+	for i := range p.Languages {
+		lang := &p.Languages[i]
+		langPath := fmt.Sprintf("%s.languages[%d]", path, i)
+
+		v.id(langPath, lang.ID, ids, i)
+		v.language(langPath, lang)
+	}
+
+	// Aliases must identify one language
+	aliases := make(map[string]string) // alias -> path
+	for i, lang := range p.Languages {
+		for j, alias := range lang.Aliases {
+			aliasPath := fmt.Sprintf("%s.languages[%d].aliases[%d]", path, i, j)
+			if alias == "" {
+				v.addf(aliasPath, "must not be empty")
+				continue
+			}
+			if !IsValidID(alias) {
+				v.addf(aliasPath, "invalid alias '%s', must match %s without '..'", alias, idPattern)
+				continue
+			}
+			if k, ok := ids[alias]; ok && k != i {
+				v.addf(aliasPath, "conflicts with the language ID '%s'", alias)
+			}
+			if prev, ok := aliases[alias]; ok {
+				v.addf(aliasPath, "duplicated alias '%s', also at %s", alias, prev)
+				continue
+			}
+			aliases[alias] = aliasPath
+		}
+	}
+}
+
+func (v *validator) language(path string, l *Language) {
+	if len(l.Processors) == 0 {
+		v.addf(path+".processors", "must have at least one processor")
+	}
+	for i, ext := range l.FileExtensions {
+		if !strings.HasPrefix(ext, ".") {
+			v.addf(fmt.Sprintf("%s.file_extensions[%d]", path, i), "must start with '.': '%s'", ext)
+		}
+	}
+	for i, name := range l.Shebangs {
+		if name == "" || strings.ContainsAny(name, "/ \t") {
+			v.addf(fmt.Sprintf("%s.shebangs[%d]", path, i), "must be an interpreter name without paths: '%s'", name)
+		}
+	}
+
+	ids := make(map[string]int)
+	for i := range l.Processors {
+		proc := &l.Processors[i]
+		procPath := fmt.Sprintf("%s.processors[%d]", path, i)
+
+		v.id(procPath, proc.ID, ids, i)
+		v.processor(procPath, proc)
+	}
+	// Replacements are checked after all IDs are known
+	for i := range l.Processors {
+		v.lifecycle(fmt.Sprintf("%s.processors[%d]", path, i), l.Processors[i].ID, &l.Processors[i].Lifecycle, ids)
+	}
+
+	if l.DefaultProcessor != "" {
+		if i, ok := ids[l.DefaultProcessor]; !ok {
+			v.addf(path+".default_processor", "unknown processor '%s'", l.DefaultProcessor)
+		} else if l.Processors[i].IsDisabled() {
+			v.addf(path+".default_processor", "must not be disabled: '%s'", l.DefaultProcessor)
+		}
+	}
+}
+
+func (v *validator) processor(path string, p *Processor) {
+	if p.DockerImage == "" {
+		v.addf(path+".docker_image", "must not be empty")
+	}
+	if p.DockerImageDigest != "" && !digestPattern.MatchString(p.DockerImageDigest) {
+		v.addf(path+".docker_image_digest", "must be in the form of 'sha256:<hex>': '%s'", p.DockerImageDigest)
+	}
+
+	if len(p.VersionCmd) > 0 && p.VersionCmd[0] == "" {
+		v.addf(path+".version_cmd[0]", "command must not be empty")
+	}
+
+	if len(p.Tasks) == 0 {
+		v.addf(path+".tasks", "must have at least one task")
+	}
+
+	ids := make(map[string]int)
+	for i := range p.Tasks {
+		task := &p.Tasks[i]
+		taskPath := fmt.Sprintf("%s.tasks[%d]", path, i)
+
+		v.id(taskPath, task.ID, ids, i)
+		v.task(taskPath, task)
+	}
+	for i := range p.Tasks {
+		v.lifecycle(fmt.Sprintf("%s.tasks[%d]", path, i), p.Tasks[i].ID, &p.Tasks[i].Lifecycle, ids)
+	}
+
+	if p.DefaultTask != "" {
+		if i, ok := ids[p.DefaultTask]; !ok {
+			v.addf(path+".default_task", "unknown task '%s'", p.DefaultTask)
+		} else if p.Tasks[i].IsDisabled() {
+			v.addf(path+".default_task", "must not be disabled: '%s'", p.DefaultTask)
+		}
+	}
+}
+
+func (v *validator) task(path string, t *Task) {
+	if !contains(TaskKinds, t.Kind) {
+		v.addf(path+".kind", "unknown kind '%s', must be one of %s", t.Kind, strings.Join(TaskKinds, ", "))
+	}
+
+	if t.Compile == nil && t.Run == nil {
+		v.addf(path, "must have at least one of compile or run")
+	}
+	if t.Compile != nil {
+		v.phase(path+".compile", t.Compile)
+	}
+	if t.Run != nil {
+		v.phase(path+".run", t.Run)
+	}
+
+	if len(t.OptionGroups) > 0 && !t.hasPlaceholder("{options}") {
+		v.addf(path+".option_groups", "{options} must be in commands to use options")
+	}
+	ids := make(map[string]int)
+	for i := range t.OptionGroups {
+		groupPath := fmt.Sprintf("%s.option_groups[%d]", path, i)
+		v.id(groupPath, t.OptionGroups[i].ID, ids, i)
+		v.optionGroup(groupPath, &t.OptionGroups[i])
+	}
+
+	for i, pattern := range t.Artifacts {
+		if err := validateGlob(pattern); err != nil {
+			v.addf(fmt.Sprintf("%s.artifacts[%d]", path, i), "%s", err)
+		}
+	}
+}
+
+func (v *validator) lifecycle(path, id string, l *Lifecycle, siblingIDs map[string]int) {
+	if l.Status != "" && !contains(Statuses, l.Status) {
+		v.addf(path+".status", "unknown status '%s', must be one of %s", l.Status, strings.Join(Statuses, ", "))
+	}
+	if l.ReplacedBy == "" {
+		return
+	}
+	if l.ReplacedBy == id {
+		v.addf(path+".replaced_by", "must not be itself")
+	} else if _, ok := siblingIDs[l.ReplacedBy]; !ok {
+		v.addf(path+".replaced_by", "unknown replacement '%s'", l.ReplacedBy)
+	}
+}
+
+func (v *validator) optionGroup(path string, g *OptionGroup) {
+	if len(g.Choices) == 0 && !g.FreeForm {
+		v.addf(path, "must have choices or be free-form")
+	}
+
+	ids := make(map[string]int)
+	for i, c := range g.Choices {
+		v.id(fmt.Sprintf("%s.choices[%d]", path, i), c.ID, ids, i)
+	}
+	for i, id := range g.Default {
+		if _, ok := ids[id]; !ok {
+			v.addf(fmt.Sprintf("%s.default[%d]", path, i), "unknown choice '%s'", id)
+		}
+	}
+	if len(g.Default) > 1 && !g.Multiple {
+		v.addf(path+".default", "must have at most one choice unless multiple")
+	}
+
+	if g.FreeForm && len(g.Safelist) == 0 {
+		v.addf(path+".safelist", "must not be empty for free-form options")
+	}
+	for i, pattern := range g.Safelist {
+		if _, err := compileSafelistPattern(pattern); err != nil {
+			v.addf(fmt.Sprintf("%s.safelist[%d]", path, i), "invalid pattern: %s", err)
+		}
+	}
+}
+
+func (v *validator) phase(path string, p *PhasedTask) {
+	if len(p.Cmd) == 0 {
+		v.addf(path+".cmd", "must not be empty")
+	}
+	if len(p.Cmd) > 0 && p.Cmd[0] == "" {
+		v.addf(path+".cmd[0]", "command must not be empty")
+	}
+
+	if l := p.Limits; l != nil {
+		v.limits(path+".limits", l)
+	}
+
+	for name := range p.Env {
+		if !IsValidEnvName(name) {
+			v.addf(path+".env", "invalid name of environment variable: '%s'", name)
+		}
+	}
+	for i, name := range p.AllowedEnv {
+		if !IsValidEnvName(name) {
+			v.addf(fmt.Sprintf("%s.allowed_env[%d]", path, i), "invalid name of environment variable: '%s'", name)
+		}
+	}
+}
+
+func (v *validator) limits(path string, l *Limits) {
+	nonNegative := []struct {
+		name  string
+		value int64
+	}{
+		{"cpu_time", l.CPUTime},
+		{"wall_time", l.WallTime},
+		{"memory", l.Memory},
+		{"cpu_quota", l.CPUQuota},
+		{"cpu_period", l.CPUPeriod},
+		{"cores", int64(l.Cores)},
+	}
+	for _, f := range nonNegative {
+		if f.value < 0 {
+			v.addf(path+"."+f.name, "must not be negative: %d", f.value)
+		}
+	}
+
+	// -1 means unlimited swap
+	if l.MemorySwap < -1 {
+		v.addf(path+".memory_swap", "must be -1 or positive: %d", l.MemorySwap)
+	}
+	if l.MemorySwap > 0 && l.Memory > 0 && l.MemorySwap < l.Memory {
+		v.addf(path+".memory_swap", "must not be less than memory: %d < %d", l.MemorySwap, l.Memory)
+	}
+}
+
+// id checks that the id is valid and unique among siblings.
+func (v *validator) id(path string, id string, seen map[string]int, index int) {
+	if id == "" {
+		v.addf(path+".id", "must not be empty")
+		return
+	}
+	if !IsValidID(id) {
+		v.addf(path+".id", "invalid id '%s', must match %s without '..'", id, idPattern)
+		return
+	}
+	if prev, ok := seen[id]; ok {
+		v.addf(path+".id", "duplicated id '%s' (also at index %d)", id, prev)
+		return
+	}
+	seen[id] = index
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,411 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: proto/api/v1/admin.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/yutopp/proclet/pkg/proto/api/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "proto.api.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceGetProfileProcedure is the fully-qualified name of the AdminService's GetProfile RPC.
+	AdminServiceGetProfileProcedure = "/proto.api.v1.AdminService/GetProfile"
+	// AdminServiceCreateLanguageProcedure is the fully-qualified name of the AdminService's
+	// CreateLanguage RPC.
+	AdminServiceCreateLanguageProcedure = "/proto.api.v1.AdminService/CreateLanguage"
+	// AdminServiceUpdateLanguageProcedure is the fully-qualified name of the AdminService's
+	// UpdateLanguage RPC.
+	AdminServiceUpdateLanguageProcedure = "/proto.api.v1.AdminService/UpdateLanguage"
+	// AdminServiceDeleteLanguageProcedure is the fully-qualified name of the AdminService's
+	// DeleteLanguage RPC.
+	AdminServiceDeleteLanguageProcedure = "/proto.api.v1.AdminService/DeleteLanguage"
+	// AdminServiceCreateProcessorProcedure is the fully-qualified name of the AdminService's
+	// CreateProcessor RPC.
+	AdminServiceCreateProcessorProcedure = "/proto.api.v1.AdminService/CreateProcessor"
+	// AdminServiceUpdateProcessorProcedure is the fully-qualified name of the AdminService's
+	// UpdateProcessor RPC.
+	AdminServiceUpdateProcessorProcedure = "/proto.api.v1.AdminService/UpdateProcessor"
+	// AdminServiceDeleteProcessorProcedure is the fully-qualified name of the AdminService's
+	// DeleteProcessor RPC.
+	AdminServiceDeleteProcessorProcedure = "/proto.api.v1.AdminService/DeleteProcessor"
+	// AdminServiceCreateTaskProcedure is the fully-qualified name of the AdminService's CreateTask RPC.
+	AdminServiceCreateTaskProcedure = "/proto.api.v1.AdminService/CreateTask"
+	// AdminServiceUpdateTaskProcedure is the fully-qualified name of the AdminService's UpdateTask RPC.
+	AdminServiceUpdateTaskProcedure = "/proto.api.v1.AdminService/UpdateTask"
+	// AdminServiceDeleteTaskProcedure is the fully-qualified name of the AdminService's DeleteTask RPC.
+	AdminServiceDeleteTaskProcedure = "/proto.api.v1.AdminService/DeleteTask"
+	// AdminServiceSetEnabledProcedure is the fully-qualified name of the AdminService's SetEnabled RPC.
+	AdminServiceSetEnabledProcedure = "/proto.api.v1.AdminService/SetEnabled"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	adminServiceServiceDescriptor               = v1.File_proto_api_v1_admin_proto.Services().ByName("AdminService")
+	adminServiceGetProfileMethodDescriptor      = adminServiceServiceDescriptor.Methods().ByName("GetProfile")
+	adminServiceCreateLanguageMethodDescriptor  = adminServiceServiceDescriptor.Methods().ByName("CreateLanguage")
+	adminServiceUpdateLanguageMethodDescriptor  = adminServiceServiceDescriptor.Methods().ByName("UpdateLanguage")
+	adminServiceDeleteLanguageMethodDescriptor  = adminServiceServiceDescriptor.Methods().ByName("DeleteLanguage")
+	adminServiceCreateProcessorMethodDescriptor = adminServiceServiceDescriptor.Methods().ByName("CreateProcessor")
+	adminServiceUpdateProcessorMethodDescriptor = adminServiceServiceDescriptor.Methods().ByName("UpdateProcessor")
+	adminServiceDeleteProcessorMethodDescriptor = adminServiceServiceDescriptor.Methods().ByName("DeleteProcessor")
+	adminServiceCreateTaskMethodDescriptor      = adminServiceServiceDescriptor.Methods().ByName("CreateTask")
+	adminServiceUpdateTaskMethodDescriptor      = adminServiceServiceDescriptor.Methods().ByName("UpdateTask")
+	adminServiceDeleteTaskMethodDescriptor      = adminServiceServiceDescriptor.Methods().ByName("DeleteTask")
+	adminServiceSetEnabledMethodDescriptor      = adminServiceServiceDescriptor.Methods().ByName("SetEnabled")
+)
+
+// AdminServiceClient is a client for the proto.api.v1.AdminService service.
+type AdminServiceClient interface {
+	GetProfile(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetProfileResponse], error)
+	CreateLanguage(context.Context, *connect.Request[v1.CreateLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateLanguage(context.Context, *connect.Request[v1.UpdateLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteLanguage(context.Context, *connect.Request[v1.DeleteLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	CreateProcessor(context.Context, *connect.Request[v1.CreateProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateProcessor(context.Context, *connect.Request[v1.UpdateProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteProcessor(context.Context, *connect.Request[v1.DeleteProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	CreateTask(context.Context, *connect.Request[v1.CreateTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateTask(context.Context, *connect.Request[v1.UpdateTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteTask(context.Context, *connect.Request[v1.DeleteTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetEnabled enables or disables the task if task_id is set, else the processor if processor_id is set, else the language.
+	SetEnabled(context.Context, *connect.Request[v1.SetEnabledRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAdminServiceClient constructs a client for the proto.api.v1.AdminService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &adminServiceClient{
+		getProfile: connect.NewClient[emptypb.Empty, v1.GetProfileResponse](
+			httpClient,
+			baseURL+AdminServiceGetProfileProcedure,
+			connect.WithSchema(adminServiceGetProfileMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createLanguage: connect.NewClient[v1.CreateLanguageRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceCreateLanguageProcedure,
+			connect.WithSchema(adminServiceCreateLanguageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateLanguage: connect.NewClient[v1.UpdateLanguageRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceUpdateLanguageProcedure,
+			connect.WithSchema(adminServiceUpdateLanguageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteLanguage: connect.NewClient[v1.DeleteLanguageRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceDeleteLanguageProcedure,
+			connect.WithSchema(adminServiceDeleteLanguageMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createProcessor: connect.NewClient[v1.CreateProcessorRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceCreateProcessorProcedure,
+			connect.WithSchema(adminServiceCreateProcessorMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateProcessor: connect.NewClient[v1.UpdateProcessorRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceUpdateProcessorProcedure,
+			connect.WithSchema(adminServiceUpdateProcessorMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteProcessor: connect.NewClient[v1.DeleteProcessorRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceDeleteProcessorProcedure,
+			connect.WithSchema(adminServiceDeleteProcessorMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		createTask: connect.NewClient[v1.CreateTaskRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceCreateTaskProcedure,
+			connect.WithSchema(adminServiceCreateTaskMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		updateTask: connect.NewClient[v1.UpdateTaskRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceUpdateTaskProcedure,
+			connect.WithSchema(adminServiceUpdateTaskMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTask: connect.NewClient[v1.DeleteTaskRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceDeleteTaskProcedure,
+			connect.WithSchema(adminServiceDeleteTaskMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		setEnabled: connect.NewClient[v1.SetEnabledRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AdminServiceSetEnabledProcedure,
+			connect.WithSchema(adminServiceSetEnabledMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	getProfile      *connect.Client[emptypb.Empty, v1.GetProfileResponse]
+	createLanguage  *connect.Client[v1.CreateLanguageRequest, emptypb.Empty]
+	updateLanguage  *connect.Client[v1.UpdateLanguageRequest, emptypb.Empty]
+	deleteLanguage  *connect.Client[v1.DeleteLanguageRequest, emptypb.Empty]
+	createProcessor *connect.Client[v1.CreateProcessorRequest, emptypb.Empty]
+	updateProcessor *connect.Client[v1.UpdateProcessorRequest, emptypb.Empty]
+	deleteProcessor *connect.Client[v1.DeleteProcessorRequest, emptypb.Empty]
+	createTask      *connect.Client[v1.CreateTaskRequest, emptypb.Empty]
+	updateTask      *connect.Client[v1.UpdateTaskRequest, emptypb.Empty]
+	deleteTask      *connect.Client[v1.DeleteTaskRequest, emptypb.Empty]
+	setEnabled      *connect.Client[v1.SetEnabledRequest, emptypb.Empty]
+}
+
+// GetProfile calls proto.api.v1.AdminService.GetProfile.
+func (c *adminServiceClient) GetProfile(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetProfileResponse], error) {
+	return c.getProfile.CallUnary(ctx, req)
+}
+
+// CreateLanguage calls proto.api.v1.AdminService.CreateLanguage.
+func (c *adminServiceClient) CreateLanguage(ctx context.Context, req *connect.Request[v1.CreateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.createLanguage.CallUnary(ctx, req)
+}
+
+// UpdateLanguage calls proto.api.v1.AdminService.UpdateLanguage.
+func (c *adminServiceClient) UpdateLanguage(ctx context.Context, req *connect.Request[v1.UpdateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.updateLanguage.CallUnary(ctx, req)
+}
+
+// DeleteLanguage calls proto.api.v1.AdminService.DeleteLanguage.
+func (c *adminServiceClient) DeleteLanguage(ctx context.Context, req *connect.Request[v1.DeleteLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteLanguage.CallUnary(ctx, req)
+}
+
+// CreateProcessor calls proto.api.v1.AdminService.CreateProcessor.
+func (c *adminServiceClient) CreateProcessor(ctx context.Context, req *connect.Request[v1.CreateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.createProcessor.CallUnary(ctx, req)
+}
+
+// UpdateProcessor calls proto.api.v1.AdminService.UpdateProcessor.
+func (c *adminServiceClient) UpdateProcessor(ctx context.Context, req *connect.Request[v1.UpdateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.updateProcessor.CallUnary(ctx, req)
+}
+
+// DeleteProcessor calls proto.api.v1.AdminService.DeleteProcessor.
+func (c *adminServiceClient) DeleteProcessor(ctx context.Context, req *connect.Request[v1.DeleteProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteProcessor.CallUnary(ctx, req)
+}
+
+// CreateTask calls proto.api.v1.AdminService.CreateTask.
+func (c *adminServiceClient) CreateTask(ctx context.Context, req *connect.Request[v1.CreateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.createTask.CallUnary(ctx, req)
+}
+
+// UpdateTask calls proto.api.v1.AdminService.UpdateTask.
+func (c *adminServiceClient) UpdateTask(ctx context.Context, req *connect.Request[v1.UpdateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.updateTask.CallUnary(ctx, req)
+}
+
+// DeleteTask calls proto.api.v1.AdminService.DeleteTask.
+func (c *adminServiceClient) DeleteTask(ctx context.Context, req *connect.Request[v1.DeleteTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteTask.CallUnary(ctx, req)
+}
+
+// SetEnabled calls proto.api.v1.AdminService.SetEnabled.
+func (c *adminServiceClient) SetEnabled(ctx context.Context, req *connect.Request[v1.SetEnabledRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.setEnabled.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the proto.api.v1.AdminService service.
+type AdminServiceHandler interface {
+	GetProfile(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetProfileResponse], error)
+	CreateLanguage(context.Context, *connect.Request[v1.CreateLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateLanguage(context.Context, *connect.Request[v1.UpdateLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteLanguage(context.Context, *connect.Request[v1.DeleteLanguageRequest]) (*connect.Response[emptypb.Empty], error)
+	CreateProcessor(context.Context, *connect.Request[v1.CreateProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateProcessor(context.Context, *connect.Request[v1.UpdateProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteProcessor(context.Context, *connect.Request[v1.DeleteProcessorRequest]) (*connect.Response[emptypb.Empty], error)
+	CreateTask(context.Context, *connect.Request[v1.CreateTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	UpdateTask(context.Context, *connect.Request[v1.UpdateTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	DeleteTask(context.Context, *connect.Request[v1.DeleteTaskRequest]) (*connect.Response[emptypb.Empty], error)
+	// SetEnabled enables or disables the task if task_id is set, else the processor if processor_id is set, else the language.
+	SetEnabled(context.Context, *connect.Request[v1.SetEnabledRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceGetProfileHandler := connect.NewUnaryHandler(
+		AdminServiceGetProfileProcedure,
+		svc.GetProfile,
+		connect.WithSchema(adminServiceGetProfileMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateLanguageHandler := connect.NewUnaryHandler(
+		AdminServiceCreateLanguageProcedure,
+		svc.CreateLanguage,
+		connect.WithSchema(adminServiceCreateLanguageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceUpdateLanguageHandler := connect.NewUnaryHandler(
+		AdminServiceUpdateLanguageProcedure,
+		svc.UpdateLanguage,
+		connect.WithSchema(adminServiceUpdateLanguageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDeleteLanguageHandler := connect.NewUnaryHandler(
+		AdminServiceDeleteLanguageProcedure,
+		svc.DeleteLanguage,
+		connect.WithSchema(adminServiceDeleteLanguageMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateProcessorHandler := connect.NewUnaryHandler(
+		AdminServiceCreateProcessorProcedure,
+		svc.CreateProcessor,
+		connect.WithSchema(adminServiceCreateProcessorMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceUpdateProcessorHandler := connect.NewUnaryHandler(
+		AdminServiceUpdateProcessorProcedure,
+		svc.UpdateProcessor,
+		connect.WithSchema(adminServiceUpdateProcessorMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDeleteProcessorHandler := connect.NewUnaryHandler(
+		AdminServiceDeleteProcessorProcedure,
+		svc.DeleteProcessor,
+		connect.WithSchema(adminServiceDeleteProcessorMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCreateTaskHandler := connect.NewUnaryHandler(
+		AdminServiceCreateTaskProcedure,
+		svc.CreateTask,
+		connect.WithSchema(adminServiceCreateTaskMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceUpdateTaskHandler := connect.NewUnaryHandler(
+		AdminServiceUpdateTaskProcedure,
+		svc.UpdateTask,
+		connect.WithSchema(adminServiceUpdateTaskMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceDeleteTaskHandler := connect.NewUnaryHandler(
+		AdminServiceDeleteTaskProcedure,
+		svc.DeleteTask,
+		connect.WithSchema(adminServiceDeleteTaskMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetEnabledHandler := connect.NewUnaryHandler(
+		AdminServiceSetEnabledProcedure,
+		svc.SetEnabled,
+		connect.WithSchema(adminServiceSetEnabledMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/proto.api.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceGetProfileProcedure:
+			adminServiceGetProfileHandler.ServeHTTP(w, r)
+		case AdminServiceCreateLanguageProcedure:
+			adminServiceCreateLanguageHandler.ServeHTTP(w, r)
+		case AdminServiceUpdateLanguageProcedure:
+			adminServiceUpdateLanguageHandler.ServeHTTP(w, r)
+		case AdminServiceDeleteLanguageProcedure:
+			adminServiceDeleteLanguageHandler.ServeHTTP(w, r)
+		case AdminServiceCreateProcessorProcedure:
+			adminServiceCreateProcessorHandler.ServeHTTP(w, r)
+		case AdminServiceUpdateProcessorProcedure:
+			adminServiceUpdateProcessorHandler.ServeHTTP(w, r)
+		case AdminServiceDeleteProcessorProcedure:
+			adminServiceDeleteProcessorHandler.ServeHTTP(w, r)
+		case AdminServiceCreateTaskProcedure:
+			adminServiceCreateTaskHandler.ServeHTTP(w, r)
+		case AdminServiceUpdateTaskProcedure:
+			adminServiceUpdateTaskHandler.ServeHTTP(w, r)
+		case AdminServiceDeleteTaskProcedure:
+			adminServiceDeleteTaskHandler.ServeHTTP(w, r)
+		case AdminServiceSetEnabledProcedure:
+			adminServiceSetEnabledHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) GetProfile(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetProfileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.GetProfile is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateLanguage(context.Context, *connect.Request[v1.CreateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.CreateLanguage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) UpdateLanguage(context.Context, *connect.Request[v1.UpdateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.UpdateLanguage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DeleteLanguage(context.Context, *connect.Request[v1.DeleteLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.DeleteLanguage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateProcessor(context.Context, *connect.Request[v1.CreateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.CreateProcessor is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) UpdateProcessor(context.Context, *connect.Request[v1.UpdateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.UpdateProcessor is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DeleteProcessor(context.Context, *connect.Request[v1.DeleteProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.DeleteProcessor is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CreateTask(context.Context, *connect.Request[v1.CreateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.CreateTask is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) UpdateTask(context.Context, *connect.Request[v1.UpdateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.UpdateTask is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) DeleteTask(context.Context, *connect.Request[v1.DeleteTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.DeleteTask is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetEnabled(context.Context, *connect.Request[v1.SetEnabledRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.AdminService.SetEnabled is not implemented"))
+}
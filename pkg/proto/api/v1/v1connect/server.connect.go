@@ -39,19 +39,30 @@ const (
 	// RunnerServiceRunOneshotProcedure is the fully-qualified name of the RunnerService's RunOneshot
 	// RPC.
 	RunnerServiceRunOneshotProcedure = "/proto.api.v1.RunnerService/RunOneshot"
+	// RunnerServiceResolveProcedure is the fully-qualified name of the RunnerService's Resolve RPC.
+	RunnerServiceResolveProcedure = "/proto.api.v1.RunnerService/Resolve"
+	// RunnerServiceDownloadArtifactProcedure is the fully-qualified name of the RunnerService's
+	// DownloadArtifact RPC.
+	RunnerServiceDownloadArtifactProcedure = "/proto.api.v1.RunnerService/DownloadArtifact"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
 var (
-	runnerServiceServiceDescriptor          = v1.File_proto_api_v1_server_proto.Services().ByName("RunnerService")
-	runnerServiceListMethodDescriptor       = runnerServiceServiceDescriptor.Methods().ByName("List")
-	runnerServiceRunOneshotMethodDescriptor = runnerServiceServiceDescriptor.Methods().ByName("RunOneshot")
+	runnerServiceServiceDescriptor                = v1.File_proto_api_v1_server_proto.Services().ByName("RunnerService")
+	runnerServiceListMethodDescriptor             = runnerServiceServiceDescriptor.Methods().ByName("List")
+	runnerServiceRunOneshotMethodDescriptor       = runnerServiceServiceDescriptor.Methods().ByName("RunOneshot")
+	runnerServiceResolveMethodDescriptor          = runnerServiceServiceDescriptor.Methods().ByName("Resolve")
+	runnerServiceDownloadArtifactMethodDescriptor = runnerServiceServiceDescriptor.Methods().ByName("DownloadArtifact")
 )
 
 // RunnerServiceClient is a client for the proto.api.v1.RunnerService service.
 type RunnerServiceClient interface {
 	List(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListResponse], error)
 	RunOneshot(context.Context, *connect.Request[v1.RunOneshotRequest]) (*connect.ServerStreamForClient[v1.RunOneshotResponse], error)
+	// Resolve tells which language, processor and task RunOneshot would use for the request.
+	Resolve(context.Context, *connect.Request[v1.ResolveRequest]) (*connect.Response[v1.ResolveResponse], error)
+	// DownloadArtifact streams content of an artifact by the handle until it expires.
+	DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest]) (*connect.ServerStreamForClient[v1.DownloadArtifactResponse], error)
 }
 
 // NewRunnerServiceClient constructs a client for the proto.api.v1.RunnerService service. By
@@ -76,13 +87,27 @@ func NewRunnerServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(runnerServiceRunOneshotMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		resolve: connect.NewClient[v1.ResolveRequest, v1.ResolveResponse](
+			httpClient,
+			baseURL+RunnerServiceResolveProcedure,
+			connect.WithSchema(runnerServiceResolveMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		downloadArtifact: connect.NewClient[v1.DownloadArtifactRequest, v1.DownloadArtifactResponse](
+			httpClient,
+			baseURL+RunnerServiceDownloadArtifactProcedure,
+			connect.WithSchema(runnerServiceDownloadArtifactMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // runnerServiceClient implements RunnerServiceClient.
 type runnerServiceClient struct {
-	list       *connect.Client[emptypb.Empty, v1.ListResponse]
-	runOneshot *connect.Client[v1.RunOneshotRequest, v1.RunOneshotResponse]
+	list             *connect.Client[emptypb.Empty, v1.ListResponse]
+	runOneshot       *connect.Client[v1.RunOneshotRequest, v1.RunOneshotResponse]
+	resolve          *connect.Client[v1.ResolveRequest, v1.ResolveResponse]
+	downloadArtifact *connect.Client[v1.DownloadArtifactRequest, v1.DownloadArtifactResponse]
 }
 
 // List calls proto.api.v1.RunnerService.List.
@@ -95,10 +120,24 @@ func (c *runnerServiceClient) RunOneshot(ctx context.Context, req *connect.Reque
 	return c.runOneshot.CallServerStream(ctx, req)
 }
 
+// Resolve calls proto.api.v1.RunnerService.Resolve.
+func (c *runnerServiceClient) Resolve(ctx context.Context, req *connect.Request[v1.ResolveRequest]) (*connect.Response[v1.ResolveResponse], error) {
+	return c.resolve.CallUnary(ctx, req)
+}
+
+// DownloadArtifact calls proto.api.v1.RunnerService.DownloadArtifact.
+func (c *runnerServiceClient) DownloadArtifact(ctx context.Context, req *connect.Request[v1.DownloadArtifactRequest]) (*connect.ServerStreamForClient[v1.DownloadArtifactResponse], error) {
+	return c.downloadArtifact.CallServerStream(ctx, req)
+}
+
 // RunnerServiceHandler is an implementation of the proto.api.v1.RunnerService service.
 type RunnerServiceHandler interface {
 	List(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListResponse], error)
 	RunOneshot(context.Context, *connect.Request[v1.RunOneshotRequest], *connect.ServerStream[v1.RunOneshotResponse]) error
+	// Resolve tells which language, processor and task RunOneshot would use for the request.
+	Resolve(context.Context, *connect.Request[v1.ResolveRequest]) (*connect.Response[v1.ResolveResponse], error)
+	// DownloadArtifact streams content of an artifact by the handle until it expires.
+	DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest], *connect.ServerStream[v1.DownloadArtifactResponse]) error
 }
 
 // NewRunnerServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -119,12 +158,28 @@ func NewRunnerServiceHandler(svc RunnerServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(runnerServiceRunOneshotMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	runnerServiceResolveHandler := connect.NewUnaryHandler(
+		RunnerServiceResolveProcedure,
+		svc.Resolve,
+		connect.WithSchema(runnerServiceResolveMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	runnerServiceDownloadArtifactHandler := connect.NewServerStreamHandler(
+		RunnerServiceDownloadArtifactProcedure,
+		svc.DownloadArtifact,
+		connect.WithSchema(runnerServiceDownloadArtifactMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/proto.api.v1.RunnerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RunnerServiceListProcedure:
 			runnerServiceListHandler.ServeHTTP(w, r)
 		case RunnerServiceRunOneshotProcedure:
 			runnerServiceRunOneshotHandler.ServeHTTP(w, r)
+		case RunnerServiceResolveProcedure:
+			runnerServiceResolveHandler.ServeHTTP(w, r)
+		case RunnerServiceDownloadArtifactProcedure:
+			runnerServiceDownloadArtifactHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -141,3 +196,11 @@ func (UnimplementedRunnerServiceHandler) List(context.Context, *connect.Request[
 func (UnimplementedRunnerServiceHandler) RunOneshot(context.Context, *connect.Request[v1.RunOneshotRequest], *connect.ServerStream[v1.RunOneshotResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.RunnerService.RunOneshot is not implemented"))
 }
+
+func (UnimplementedRunnerServiceHandler) Resolve(context.Context, *connect.Request[v1.ResolveRequest]) (*connect.Response[v1.ResolveResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.RunnerService.Resolve is not implemented"))
+}
+
+func (UnimplementedRunnerServiceHandler) DownloadArtifact(context.Context, *connect.Request[v1.DownloadArtifactRequest], *connect.ServerStream[v1.DownloadArtifactResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("proto.api.v1.RunnerService.DownloadArtifact is not implemented"))
+}
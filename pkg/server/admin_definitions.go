@@ -0,0 +1,272 @@
+package server
+
+import (
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// Conversions between definitions of AdminService and the profile as written.
+// Empty lists and maps become nil to be omitted in saved files.
+
+func profileDefinition(p *domain.Profile) *apiv1pb.ProfileDefinition {
+	d := &apiv1pb.ProfileDefinition{}
+	for i := range p.Languages {
+		d.Languages = append(d.Languages, languageDefinition(&p.Languages[i]))
+	}
+	for i := range p.Templates {
+		d.Templates = append(d.Templates, processorDefinition(&p.Templates[i]))
+	}
+	return d
+}
+
+func languageDefinition(l *domain.Language) *apiv1pb.LanguageDefinition {
+	d := &apiv1pb.LanguageDefinition{
+		Id:       l.ID,
+		ShowName: l.ShowName,
+
+		Description:    l.Description,
+		FileExtensions: l.FileExtensions,
+		Shebangs:       l.Shebangs,
+
+		Aliases:          l.Aliases,
+		DefaultProcessor: l.DefaultProcessor,
+
+		Disabled: l.Disabled,
+	}
+	for i := range l.Processors {
+		d.Processors = append(d.Processors, processorDefinition(&l.Processors[i]))
+	}
+	return d
+}
+
+func processorDefinition(p *domain.Processor) *apiv1pb.ProcessorDefinition {
+	d := &apiv1pb.ProcessorDefinition{
+		Id:          p.ID,
+		ShowName:    p.ShowName,
+		Description: p.Description,
+		Version:     p.Version,
+		VersionCmd:  p.VersionCmd,
+
+		DockerImage:       p.DockerImage,
+		DockerImageDigest: p.DockerImageDigest,
+
+		DefaultFilename: p.DefaultFilename,
+		OutputFilename:  p.OutputFilename,
+		SampleCode:      p.SampleCode,
+
+		DefaultTask: p.DefaultTask,
+
+		Extends: p.Extends,
+		Vars:    p.Vars,
+
+		Status:        p.Status,
+		StatusMessage: p.StatusMessage,
+		ReplacedBy:    p.ReplacedBy,
+	}
+	for i := range p.Tasks {
+		d.Tasks = append(d.Tasks, taskDefinition(&p.Tasks[i]))
+	}
+	return d
+}
+
+func taskDefinition(t *domain.Task) *apiv1pb.TaskDefinition {
+	d := &apiv1pb.TaskDefinition{
+		Id:          t.ID,
+		ShowName:    t.ShowName,
+		Description: t.Description,
+
+		Kind: t.Kind,
+
+		Compile: phaseDefinition(t.Compile),
+		Run:     phaseDefinition(t.Run),
+
+		AllowRunArgs: t.AllowRunArgs,
+		Artifacts:    t.Artifacts,
+
+		Status:        t.Status,
+		StatusMessage: t.StatusMessage,
+		ReplacedBy:    t.ReplacedBy,
+	}
+	for _, g := range t.OptionGroups {
+		group := &apiv1pb.OptionGroupDefinition{
+			Id:       g.ID,
+			ShowName: g.ShowName,
+
+			Default:  g.Default,
+			Multiple: g.Multiple,
+
+			FreeForm: g.FreeForm,
+			Safelist: g.Safelist,
+		}
+		for _, c := range g.Choices {
+			group.Choices = append(group.Choices, &apiv1pb.OptionChoiceDefinition{
+				Id:       c.ID,
+				ShowName: c.ShowName,
+				Args:     c.Args,
+			})
+		}
+		d.OptionGroups = append(d.OptionGroups, group)
+	}
+	return d
+}
+
+func phaseDefinition(p *domain.PhasedTask) *apiv1pb.PhaseDefinition {
+	if p == nil {
+		return nil
+	}
+
+	d := &apiv1pb.PhaseDefinition{
+		Cmd:   p.Cmd,
+		Shell: p.Shell,
+
+		Env:        p.Env,
+		AllowedEnv: p.AllowedEnv,
+	}
+	if l := p.Limits; l != nil {
+		d.Limits = &apiv1pb.LimitsDefinition{
+			CpuTime:    l.CPUTime,
+			WallTime:   l.WallTime,
+			Memory:     l.Memory,
+			MemorySwap: l.MemorySwap,
+			CpuQuota:   l.CPUQuota,
+			CpuPeriod:  l.CPUPeriod,
+			Cores:      int64(l.Cores),
+		}
+	}
+	return d
+}
+
+func languageFromDefinition(d *apiv1pb.LanguageDefinition) domain.Language {
+	l := domain.Language{
+		ID:       d.Id,
+		ShowName: d.ShowName,
+
+		Description:    d.Description,
+		FileExtensions: nonEmpty(d.FileExtensions),
+		Shebangs:       nonEmpty(d.Shebangs),
+
+		Aliases:          nonEmpty(d.Aliases),
+		DefaultProcessor: d.DefaultProcessor,
+
+		Disabled: d.Disabled,
+	}
+	for _, p := range d.Processors {
+		l.Processors = append(l.Processors, processorFromDefinition(p))
+	}
+	return l
+}
+
+func processorFromDefinition(d *apiv1pb.ProcessorDefinition) domain.Processor {
+	p := domain.Processor{
+		ID:          d.Id,
+		ShowName:    d.ShowName,
+		Description: d.Description,
+		Version:     d.Version,
+		VersionCmd:  nonEmpty(d.VersionCmd),
+
+		DockerImage:       d.DockerImage,
+		DockerImageDigest: d.DockerImageDigest,
+
+		DefaultFilename: d.DefaultFilename,
+		OutputFilename:  d.OutputFilename,
+		SampleCode:      d.SampleCode,
+
+		DefaultTask: d.DefaultTask,
+
+		Extends: d.Extends,
+		Vars:    nonEmptyMap(d.Vars),
+
+		Lifecycle: domain.Lifecycle{
+			Status:        d.Status,
+			StatusMessage: d.StatusMessage,
+			ReplacedBy:    d.ReplacedBy,
+		},
+	}
+	for _, t := range d.Tasks {
+		p.Tasks = append(p.Tasks, taskFromDefinition(t))
+	}
+	return p
+}
+
+func taskFromDefinition(d *apiv1pb.TaskDefinition) domain.Task {
+	t := domain.Task{
+		ID:          d.Id,
+		ShowName:    d.ShowName,
+		Description: d.Description,
+
+		Kind: d.Kind,
+
+		Compile: phaseFromDefinition(d.Compile),
+		Run:     phaseFromDefinition(d.Run),
+
+		AllowRunArgs: d.AllowRunArgs,
+		Artifacts:    nonEmpty(d.Artifacts),
+
+		Lifecycle: domain.Lifecycle{
+			Status:        d.Status,
+			StatusMessage: d.StatusMessage,
+			ReplacedBy:    d.ReplacedBy,
+		},
+	}
+	for _, g := range d.OptionGroups {
+		group := domain.OptionGroup{
+			ID:       g.Id,
+			ShowName: g.ShowName,
+
+			Default:  nonEmpty(g.Default),
+			Multiple: g.Multiple,
+
+			FreeForm: g.FreeForm,
+			Safelist: nonEmpty(g.Safelist),
+		}
+		for _, c := range g.Choices {
+			group.Choices = append(group.Choices, domain.OptionChoice{
+				ID:       c.Id,
+				ShowName: c.ShowName,
+				Args:     c.Args,
+			})
+		}
+		t.OptionGroups = append(t.OptionGroups, group)
+	}
+	return t
+}
+
+func phaseFromDefinition(d *apiv1pb.PhaseDefinition) *domain.PhasedTask {
+	if d == nil {
+		return nil
+	}
+
+	p := &domain.PhasedTask{
+		Cmd:   d.Cmd,
+		Shell: d.Shell,
+
+		Env:        nonEmptyMap(d.Env),
+		AllowedEnv: nonEmpty(d.AllowedEnv),
+	}
+	if l := d.Limits; l != nil {
+		p.Limits = &domain.Limits{
+			CPUTime:    l.CpuTime,
+			WallTime:   l.WallTime,
+			Memory:     l.Memory,
+			MemorySwap: l.MemorySwap,
+			CPUQuota:   l.CpuQuota,
+			CPUPeriod:  l.CpuPeriod,
+			Cores:      int(l.Cores),
+		}
+	}
+	return p
+}
+
+func nonEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func nonEmptyMap(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+	apiv1connect "github.com/yutopp/proclet/pkg/proto/api/v1/v1connect"
+)
+
+// AdminServer implements the AdminServiceHandler interface to manage the catalog of the Server at runtime.
+type AdminServer struct {
+	server *Server
+}
+
+var _ apiv1connect.AdminServiceHandler = (*AdminServer)(nil)
+
+// RegisterAdmin registers AdminService if the admin token is configured.
+func RegisterAdmin(mux *http.ServeMux, srv *Server) {
+	if srv.config.AdminToken == "" {
+		return
+	}
+
+	loggingInterceptor := NewLoggingInterceptor(srv.config.Logger)
+	errorInterceptor := NewErrorInterceptor(srv.config.Logger)
+	authInterceptor := NewAuthInterceptor(srv.config.AdminToken)
+	path, handler := apiv1connect.NewAdminServiceHandler(
+		&AdminServer{server: srv},
+		connect.WithInterceptors(loggingInterceptor, errorInterceptor, authInterceptor),
+	)
+	mux.Handle(path, handler)
+}
+
+func (a *AdminServer) GetProfile(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[apiv1pb.GetProfileResponse], error) {
+	catalog := a.server.profiles.Catalog()
+
+	return connect.NewResponse(&apiv1pb.GetProfileResponse{Profile: profileDefinition(catalog.Raw)}), nil
+}
+
+func (a *AdminServer) CreateLanguage(_ context.Context, req *connect.Request[apiv1pb.CreateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Language == nil {
+		return nil, invalidArgument("language", "definition is required")
+	}
+	lang := languageFromDefinition(req.Msg.Language)
+
+	return a.update(func(profile *domain.Profile) error {
+		return profile.AddLanguage(lang)
+	})
+}
+
+func (a *AdminServer) UpdateLanguage(_ context.Context, req *connect.Request[apiv1pb.UpdateLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Language == nil {
+		return nil, invalidArgument("language", "definition is required")
+	}
+	lang := languageFromDefinition(req.Msg.Language)
+
+	return a.update(func(profile *domain.Profile) error {
+		existing, err := profile.Language(req.Msg.LanguageId)
+		if err != nil {
+			return err
+		}
+		if lang.ID != existing.ID {
+			if _, err := profile.Language(lang.ID); err == nil {
+				return errors.Wrapf(domain.ErrAlreadyExists, "language '%s'", lang.ID)
+			}
+		}
+		if lang.Processors == nil {
+			lang.Processors = existing.Processors
+		}
+		*existing = lang
+		return nil
+	})
+}
+
+func (a *AdminServer) DeleteLanguage(_ context.Context, req *connect.Request[apiv1pb.DeleteLanguageRequest]) (*connect.Response[emptypb.Empty], error) {
+	return a.update(func(profile *domain.Profile) error {
+		return profile.RemoveLanguage(req.Msg.LanguageId)
+	})
+}
+
+func (a *AdminServer) CreateProcessor(_ context.Context, req *connect.Request[apiv1pb.CreateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Processor == nil {
+		return nil, invalidArgument("processor", "definition is required")
+	}
+	proc := processorFromDefinition(req.Msg.Processor)
+
+	return a.update(func(profile *domain.Profile) error {
+		lang, err := profile.Language(req.Msg.LanguageId)
+		if err != nil {
+			return err
+		}
+		return lang.AddProcessor(proc)
+	})
+}
+
+func (a *AdminServer) UpdateProcessor(_ context.Context, req *connect.Request[apiv1pb.UpdateProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Processor == nil {
+		return nil, invalidArgument("processor", "definition is required")
+	}
+	proc := processorFromDefinition(req.Msg.Processor)
+
+	return a.update(func(profile *domain.Profile) error {
+		lang, err := profile.Language(req.Msg.LanguageId)
+		if err != nil {
+			return err
+		}
+		existing, err := lang.Processor(req.Msg.ProcessorId)
+		if err != nil {
+			return err
+		}
+		if proc.ID != existing.ID {
+			if _, err := lang.Processor(proc.ID); err == nil {
+				return errors.Wrapf(domain.ErrAlreadyExists, "processor '%s'", proc.ID)
+			}
+		}
+		if proc.Tasks == nil {
+			proc.Tasks = existing.Tasks
+		}
+		*existing = proc
+		return nil
+	})
+}
+
+func (a *AdminServer) DeleteProcessor(_ context.Context, req *connect.Request[apiv1pb.DeleteProcessorRequest]) (*connect.Response[emptypb.Empty], error) {
+	return a.update(func(profile *domain.Profile) error {
+		lang, err := profile.Language(req.Msg.LanguageId)
+		if err != nil {
+			return err
+		}
+		return lang.RemoveProcessor(req.Msg.ProcessorId)
+	})
+}
+
+func (a *AdminServer) CreateTask(_ context.Context, req *connect.Request[apiv1pb.CreateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Task == nil {
+		return nil, invalidArgument("task", "definition is required")
+	}
+	task := taskFromDefinition(req.Msg.Task)
+
+	return a.update(func(profile *domain.Profile) error {
+		proc, err := findRawProcessor(profile, req.Msg.LanguageId, req.Msg.ProcessorId)
+		if err != nil {
+			return err
+		}
+		return proc.AddTask(task)
+	})
+}
+
+func (a *AdminServer) UpdateTask(_ context.Context, req *connect.Request[apiv1pb.UpdateTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	if req.Msg.Task == nil {
+		return nil, invalidArgument("task", "definition is required")
+	}
+	task := taskFromDefinition(req.Msg.Task)
+
+	return a.update(func(profile *domain.Profile) error {
+		proc, err := findRawProcessor(profile, req.Msg.LanguageId, req.Msg.ProcessorId)
+		if err != nil {
+			return err
+		}
+		existing, err := proc.Task(req.Msg.TaskId)
+		if err != nil {
+			return err
+		}
+		if task.ID != existing.ID {
+			if _, err := proc.Task(task.ID); err == nil {
+				return errors.Wrapf(domain.ErrAlreadyExists, "task '%s'", task.ID)
+			}
+		}
+		*existing = task
+		return nil
+	})
+}
+
+func (a *AdminServer) DeleteTask(_ context.Context, req *connect.Request[apiv1pb.DeleteTaskRequest]) (*connect.Response[emptypb.Empty], error) {
+	return a.update(func(profile *domain.Profile) error {
+		proc, err := findRawProcessor(profile, req.Msg.LanguageId, req.Msg.ProcessorId)
+		if err != nil {
+			return err
+		}
+		return proc.RemoveTask(req.Msg.TaskId)
+	})
+}
+
+func (a *AdminServer) SetEnabled(_ context.Context, req *connect.Request[apiv1pb.SetEnabledRequest]) (*connect.Response[emptypb.Empty], error) {
+	// Enabling clears deprecation too. Use Update* to deprecate them.
+	setLifecycle := func(l *domain.Lifecycle) {
+		if req.Msg.Enabled {
+			*l = domain.Lifecycle{}
+			return
+		}
+		l.Status = domain.StatusDisabled // the message and the replacement are kept
+	}
+
+	return a.update(func(profile *domain.Profile) error {
+		lang, err := profile.Language(req.Msg.LanguageId)
+		if err != nil {
+			return err
+		}
+		if req.Msg.ProcessorId == "" {
+			lang.Disabled = !req.Msg.Enabled
+			return nil
+		}
+
+		proc, err := lang.Processor(req.Msg.ProcessorId)
+		if err != nil {
+			return err
+		}
+		if req.Msg.TaskId == "" {
+			setLifecycle(&proc.Lifecycle)
+			return nil
+		}
+
+		task, err := proc.Task(req.Msg.TaskId)
+		if err != nil {
+			return err
+		}
+		setLifecycle(&task.Lifecycle)
+		return nil
+	})
+}
+
+// update applies the edit to the profile. Images affected by the edit are prepared before it is served.
+// They are prepared on the server context since pulls must neither be cancelled by clients nor outlive the server.
+func (a *AdminServer) update(edit func(profile *domain.Profile) error) (*connect.Response[emptypb.Empty], error) {
+	if err := a.server.profiles.Update(a.server.ctx, edit); err != nil {
+		a.server.config.Logger.Warn("Profile update rejected", zap.Error(err))
+		return nil, err
+	}
+	a.server.detectVersions(a.server.profiles.Catalog())
+
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func findRawProcessor(profile *domain.Profile, languageID, processorID string) (*domain.Processor, error) {
+	lang, err := profile.Language(languageID)
+	if err != nil {
+		return nil, err
+	}
+	return lang.Processor(processorID)
+}
+
+type authInterceptor struct {
+	token []byte
+}
+
+var _ connect.Interceptor = (*authInterceptor)(nil)
+
+// NewAuthInterceptor rejects requests without "Authorization: Bearer <token>".
+func NewAuthInterceptor(token string) *authInterceptor {
+	return &authInterceptor{
+		token: []byte(token),
+	}
+}
+
+func (i *authInterceptor) authenticate(header http.Header) error {
+	given, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(given), i.token) != 1 {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
+	}
+	return nil
+}
+
+func (i *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		if err := i.authenticate(req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	})
+}
+
+func (i *authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		ctx context.Context,
+		conn connect.StreamingHandlerConn,
+	) error {
+		if err := i.authenticate(conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	})
+}
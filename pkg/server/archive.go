@@ -0,0 +1,226 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// archiveOverhead is the allowance for headers and paddings in a decompressed tar stream.
+const archiveOverhead = 1 * 1024 * 1024 // 1MiB
+
+// errArchiveTooLarge marks errors of exceeded limits in archives.
+var errArchiveTooLarge = errors.New("archive is too large")
+
+// requestFiles returns files of the request with entries of the archive appended.
+// Entries are validated with the files in the same way as validateFiles.
+func requestFiles(files []*apiv1pb.File, archive *apiv1pb.Archive, limits FileLimits) ([]*apiv1pb.File, error) {
+	if err := validateFiles(files, limits); err != nil {
+		return nil, err
+	}
+	if archive == nil {
+		return files, nil
+	}
+
+	entries, err := extractArchive(archive, limits.withDefaults())
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]*apiv1pb.File, 0, len(files)+len(entries))
+	all = append(all, files...)
+	all = append(all, entries...)
+	if err := checkFiles(all, limits, func(i int, sub string) string {
+		if i < 0 || i >= len(files) {
+			return "archive"
+		}
+		return fmt.Sprintf("files[%d]%s", i, sub)
+	}); err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+// extractArchive reads regular files in the archive into memory. Sizes are limited without trusting headers.
+// Links and special files are rejected, and directories are implied by paths of files.
+func extractArchive(archive *apiv1pb.Archive, limits FileLimits) ([]*apiv1pb.File, error) {
+	if int64(len(archive.Content)) > limits.MaxTotalBytes {
+		return nil, resourceExhausted("archive.content", "archive is too large: %d bytes > %d bytes", len(archive.Content), limits.MaxTotalBytes)
+	}
+
+	format := archive.Format
+	if format == apiv1pb.Archive_FORMAT_UNSPECIFIED {
+		format = detectArchiveFormat(archive.Content)
+	}
+
+	var entries []*apiv1pb.File
+	var err error
+	switch format {
+	case apiv1pb.Archive_FORMAT_TAR:
+		entries, err = extractTar(bytes.NewReader(archive.Content), limits)
+	case apiv1pb.Archive_FORMAT_TAR_GZIP:
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(bytes.NewReader(archive.Content))
+		if err != nil {
+			break
+		}
+		limited := &limitedReader{r: zr, n: limits.MaxTotalBytes + archiveOverhead}
+		entries, err = extractTar(limited, limits)
+	case apiv1pb.Archive_FORMAT_ZIP:
+		entries, err = extractZip(archive.Content, limits)
+	default:
+		return nil, invalidArgument("archive.format", "unknown archive format")
+	}
+	if errors.Is(err, errArchiveTooLarge) {
+		return nil, resourceExhausted("archive.content", "%s", err)
+	}
+	if err != nil {
+		return nil, invalidArgument("archive.content", "invalid archive: %s", err)
+	}
+
+	return entries, nil
+}
+
+func detectArchiveFormat(content []byte) apiv1pb.Archive_Format {
+	switch {
+	case bytes.HasPrefix(content, []byte{0x1f, 0x8b}):
+		return apiv1pb.Archive_FORMAT_TAR_GZIP
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")), bytes.HasPrefix(content, []byte("PK\x05\x06")):
+		return apiv1pb.Archive_FORMAT_ZIP
+	default:
+		return apiv1pb.Archive_FORMAT_TAR
+	}
+}
+
+func extractTar(r io.Reader, limits FileLimits) ([]*apiv1pb.File, error) {
+	var entries []*apiv1pb.File
+	var total int64
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeXGlobalHeader: // global headers are written by `git archive`
+			continue
+		case tar.TypeReg, tar.TypeRegA:
+		default:
+			return nil, errors.Errorf("entry '%s' is not a regular file or a directory", hdr.Name)
+		}
+
+		if len(entries) >= limits.MaxFiles {
+			return nil, errors.Mark(errors.Newf("too many files: > %d", limits.MaxFiles), errArchiveTooLarge)
+		}
+		content, err := readEntry(tr, hdr.Name, limits.MaxFileBytes)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(content))
+		if total > limits.MaxTotalBytes {
+			return nil, errors.Mark(errors.Newf("files are too large in total: > %d bytes", limits.MaxTotalBytes), errArchiveTooLarge)
+		}
+
+		entries = append(entries, &apiv1pb.File{
+			Path:       entryPath(hdr.Name),
+			Content:    content,
+			Executable: hdr.Mode&0111 != 0,
+		})
+	}
+
+	return entries, nil
+}
+
+func extractZip(content []byte, limits FileLimits) ([]*apiv1pb.File, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*apiv1pb.File
+	var total int64
+	for _, f := range zr.File {
+		mode := f.Mode()
+		if mode.IsDir() {
+			continue
+		}
+		if mode.Type() != 0 {
+			return nil, errors.Errorf("entry '%s' is not a regular file or a directory", f.Name)
+		}
+
+		if len(entries) >= limits.MaxFiles {
+			return nil, errors.Mark(errors.Newf("too many files: > %d", limits.MaxFiles), errArchiveTooLarge)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := readEntry(rc, f.Name, limits.MaxFileBytes)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(content))
+		if total > limits.MaxTotalBytes {
+			return nil, errors.Mark(errors.Newf("files are too large in total: > %d bytes", limits.MaxTotalBytes), errArchiveTooLarge)
+		}
+
+		entries = append(entries, &apiv1pb.File{
+			Path:       entryPath(f.Name),
+			Content:    content,
+			Executable: mode&fs.FileMode(0111) != 0,
+		})
+	}
+
+	return entries, nil
+}
+
+// readEntry reads at most max bytes of the entry. Sizes in headers are not trusted.
+func readEntry(r io.Reader, name string, max int64) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > max {
+		return nil, errors.Mark(errors.Newf("entry '%s' is too large: > %d bytes", name, max), errArchiveTooLarge)
+	}
+	return content, nil
+}
+
+// entryPath trims "./" commonly prefixed by archivers. Other unclean paths are rejected later.
+func entryPath(name string) string {
+	return strings.TrimPrefix(name, "./")
+}
+
+// limitedReader fails with errArchiveTooLarge instead of EOF after n bytes.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, errors.Mark(errors.New("decompressed stream is too large"), errArchiveTooLarge)
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
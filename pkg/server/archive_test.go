@@ -0,0 +1,391 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+var testArchiveLimits = FileLimits{
+	MaxFiles:      4,
+	MaxTotalBytes: 64 * 1024,
+	MaxFileBytes:  1024,
+}
+
+func tarArchive(t *testing.T, headers ...*tar.Header) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range headers {
+		if hdr.Mode == 0 && hdr.Typeflag != tar.TypeXGlobalHeader {
+			hdr.Mode = 0644
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := tw.Write(bytes.Repeat([]byte("a"), int(hdr.Size))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type zipEntry struct {
+	name string
+	mode fs.FileMode
+	size int
+}
+
+func zipArchive(t *testing.T, entries ...zipEntry) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		mode := e.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		hdr.SetMode(mode)
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(bytes.Repeat([]byte("a"), e.size)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func reg(name string, size int64) *tar.Header {
+	return &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: size}
+}
+
+func TestRequestFilesArchive(t *testing.T) {
+	// Directory headers inflate the decompressed stream without adding files
+	var dirs []*tar.Header
+	for i := 0; i < 3000; i++ {
+		dirs = append(dirs, &tar.Header{Typeflag: tar.TypeDir, Name: "d/", Mode: 0755})
+	}
+
+	tests := []struct {
+		name    string
+		files   []*apiv1pb.File
+		archive func(t *testing.T) *apiv1pb.Archive
+		paths   []string
+		code    connect.Code // 0 if it succeeds
+		field   string
+	}{
+		{
+			name: "tar",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t,
+					&tar.Header{Typeflag: tar.TypeDir, Name: "./src/", Mode: 0755},
+					reg("./src/main.c", 3),
+					&tar.Header{Typeflag: tar.TypeReg, Name: "run.sh", Mode: 0755, Size: 1},
+				)}
+			},
+			paths: []string{"src/main.c", "run.sh"},
+		},
+		{
+			name: "tar made by git archive",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t,
+					&tar.Header{Typeflag: tar.TypeXGlobalHeader, PAXRecords: map[string]string{"comment": "0123abcd"}},
+					reg("main.c", 3),
+				)}
+			},
+			paths: []string{"main.c"},
+		},
+		{
+			name: "tar.gz",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: gzipped(t, tarArchive(t, reg("main.c", 3)))}
+			},
+			paths: []string{"main.c"},
+		},
+		{
+			name:  "with files",
+			files: []*apiv1pb.File{{Path: "main.c"}},
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("lib.h", 3))}
+			},
+			paths: []string{"main.c", "lib.h"},
+		},
+		{
+			name: "zip",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: zipArchive(t,
+					zipEntry{name: "src/", mode: fs.ModeDir | 0755},
+					zipEntry{name: "src/main.c", size: 3},
+				)}
+			},
+			paths: []string{"src/main.c"},
+		},
+		{
+			name: "tar slip",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("../evil", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name: "tar absolute path",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("/etc/passwd", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name: "tar symlink",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, &tar.Header{Typeflag: tar.TypeSymlink, Name: "link", Linkname: "/etc/passwd"})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "tar hard link",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("a", 1), &tar.Header{Typeflag: tar.TypeLink, Name: "b", Linkname: "a"})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "tar device",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, &tar.Header{Typeflag: tar.TypeChar, Name: "null", Devmajor: 1, Devminor: 3})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "tar fifo",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, &tar.Header{Typeflag: tar.TypeFifo, Name: "fifo"})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "tar reserved path",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg(".proclet/x", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name: "tar path starting with a dash",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("src/-rf", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name:  "duplicated with files",
+			files: []*apiv1pb.File{{Path: "main.c"}},
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("main.c", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name:  "file as a parent",
+			files: []*apiv1pb.File{{Path: "src"}},
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("src/main.c", 1))}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name: "tar too many files",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("a", 1), reg("b", 1), reg("c", 1), reg("d", 1), reg("e", 1))}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name:  "too many files with files",
+			files: []*apiv1pb.File{{Path: "a"}, {Path: "b"}, {Path: "c"}},
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("d", 1), reg("e", 1))}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive",
+		},
+		{
+			name: "tar large file",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: tarArchive(t, reg("big", 1025))}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name: "tar.gz bomb",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: gzipped(t, tarArchive(t, reg("bomb", 8*1024*1024)))}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name: "tar.gz bomb of headers",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: gzipped(t, tarArchive(t, dirs...))}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name: "compressed archive too large",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: make([]byte, testArchiveLimits.MaxTotalBytes+1)}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name: "zip slip",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: zipArchive(t, zipEntry{name: "../../evil", size: 1})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive",
+		},
+		{
+			name: "zip symlink",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: zipArchive(t, zipEntry{name: "link", mode: fs.ModeSymlink | 0777, size: 11})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "zip device",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: zipArchive(t, zipEntry{name: "null", mode: fs.ModeDevice | fs.ModeCharDevice | 0666})}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "zip bomb",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Content: zipArchive(t, zipEntry{name: "bomb", size: 8 * 1024 * 1024})}
+			},
+			code:  connect.CodeResourceExhausted,
+			field: "archive.content",
+		},
+		{
+			name: "broken tar",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Format: apiv1pb.Archive_FORMAT_TAR, Content: []byte("not a tar")}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.content",
+		},
+		{
+			name: "unknown format",
+			archive: func(t *testing.T) *apiv1pb.Archive {
+				return &apiv1pb.Archive{Format: 99}
+			},
+			code:  connect.CodeInvalidArgument,
+			field: "archive.format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := requestFiles(tc.files, tc.archive(t), testArchiveLimits)
+			if tc.code == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %+v", err)
+				}
+				var paths []string
+				for _, f := range files {
+					paths = append(paths, f.Path)
+				}
+				if !equalStrings(paths, tc.paths) {
+					t.Fatalf("paths = %v, want %v", paths, tc.paths)
+				}
+				return
+			}
+
+			var reqErr *requestError
+			if !errors.As(err, &reqErr) {
+				t.Fatalf("error is not a request error: %+v", err)
+			}
+			if reqErr.code != tc.code || reqErr.field != tc.field {
+				t.Fatalf("got %s on '%s', want %s on '%s': %s", reqErr.code, reqErr.field, tc.code, tc.field, err)
+			}
+		})
+	}
+}
+
+func TestExtractTarExecutable(t *testing.T) {
+	content := tarArchive(t,
+		&tar.Header{Typeflag: tar.TypeReg, Name: "run.sh", Mode: 0755, Size: 1},
+		&tar.Header{Typeflag: tar.TypeReg, Name: "main.c", Mode: 0644, Size: 1},
+	)
+	files, err := extractArchive(&apiv1pb.Archive{Content: content}, testArchiveLimits.withDefaults())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || !files[0].Executable || files[1].Executable {
+		t.Fatalf("unexpected files: %v", files)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
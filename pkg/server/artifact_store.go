@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	defaultArtifactTTL           = 10 * time.Minute
+	defaultArtifactStoreMaxBytes = 256 * 1024 * 1024 // 256MiB
+	artifactSweepInterval        = time.Minute
+)
+
+var errArtifactStoreFull = errors.New("artifact store is full")
+
+// ArtifactStore keeps copies of large artifacts for DownloadArtifact until they expire.
+// Handles are random, so they are the only credentials to download.
+type ArtifactStore struct {
+	TempDir  string
+	TTL      time.Duration
+	MaxBytes int64 // total of stored artifacts
+
+	mu      sync.Mutex
+	dir     string // created on the first Put
+	entries map[string]*storedArtifact
+	total   int64
+	closed  bool
+}
+
+type storedArtifact struct {
+	size    int64
+	expires time.Time
+}
+
+func NewArtifactStore(tempDir string, ttl time.Duration, maxBytes int64) *ArtifactStore {
+	if ttl <= 0 {
+		ttl = defaultArtifactTTL
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultArtifactStoreMaxBytes
+	}
+	return &ArtifactStore{
+		TempDir:  tempDir,
+		TTL:      ttl,
+		MaxBytes: maxBytes,
+		entries:  make(map[string]*storedArtifact),
+	}
+}
+
+// Put copies the content of the size and returns the handle of it.
+// It fails with errArtifactStoreFull if the content does not fit until others expire.
+func (s *ArtifactStore) Put(r io.Reader, size int64) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	handle := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return "", errors.New("artifact store is closed")
+	}
+	s.sweep(time.Now())
+	if s.total+size > s.MaxBytes {
+		return "", errors.Mark(errors.Newf("artifact store is full: > %d bytes", s.MaxBytes), errArtifactStoreFull)
+	}
+	if s.dir == "" {
+		dir, err := os.MkdirTemp(s.TempDir, "proclet-artifacts-")
+		if err != nil {
+			return "", err
+		}
+		s.dir = dir
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, handle), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, io.LimitReader(r, size)); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to copy artifact")
+	}
+
+	s.entries[handle] = &storedArtifact{size: size, expires: time.Now().Add(s.TTL)}
+	s.total += size
+	return handle, nil
+}
+
+// Open opens the artifact by the handle. It reports false if the handle is unknown or expired.
+func (s *ArtifactStore) Open(handle string) (*os.File, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(time.Now())
+	if _, ok := s.entries[handle]; !ok {
+		return nil, false, nil
+	}
+	f, err := os.Open(filepath.Join(s.dir, handle))
+	if err != nil {
+		return nil, false, err
+	}
+	return f, true, nil
+}
+
+// SweepEvery removes expired artifacts periodically until ctx is done,
+// so they do not stay on disk while no one puts or opens artifacts.
+func (s *ArtifactStore) SweepEvery(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case now := <-t.C:
+			s.mu.Lock()
+			s.sweep(now)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// Close removes the directory with all artifacts. Later Puts fail.
+func (s *ArtifactStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.entries = make(map[string]*storedArtifact)
+	s.total = 0
+	if s.dir == "" {
+		return nil
+	}
+	return os.RemoveAll(s.dir)
+}
+
+// sweep removes expired artifacts. Files being downloaded are kept open until finished. s.mu must be held.
+func (s *ArtifactStore) sweep(now time.Time) {
+	for handle, entry := range s.entries {
+		if now.Before(entry.expires) {
+			continue
+		}
+		delete(s.entries, handle)
+		s.total -= entry.size
+		if err := os.Remove(filepath.Join(s.dir, handle)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("failed to remove artifact: %s", err)
+		}
+	}
+}
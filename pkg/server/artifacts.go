@@ -0,0 +1,188 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// ArtifactLimits limits artifacts returned by a run. Zero values mean defaults.
+type ArtifactLimits struct {
+	MaxArtifacts   int
+	MaxInlineBytes int64 // larger ones are returned by handles
+	MaxBytes       int64 // per artifact
+	MaxTotalBytes  int64
+}
+
+var DefaultArtifactLimits = ArtifactLimits{
+	MaxArtifacts:   16,
+	MaxInlineBytes: 256 * 1024,       // 256KiB
+	MaxBytes:       16 * 1024 * 1024, // 16MiB
+	MaxTotalBytes:  32 * 1024 * 1024, // 32MiB
+}
+
+func (l ArtifactLimits) withDefaults() ArtifactLimits {
+	if l.MaxArtifacts <= 0 {
+		l.MaxArtifacts = DefaultArtifactLimits.MaxArtifacts
+	}
+	if l.MaxInlineBytes <= 0 {
+		l.MaxInlineBytes = DefaultArtifactLimits.MaxInlineBytes
+	}
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = DefaultArtifactLimits.MaxBytes
+	}
+	if l.MaxTotalBytes <= 0 {
+		l.MaxTotalBytes = DefaultArtifactLimits.MaxTotalBytes
+	}
+	return l
+}
+
+// findArtifacts returns slash-separated paths of regular files matched by the task in the home directory.
+// Links are not followed since they are made by users. It reports whether more files are matched than max.
+func findArtifacts(homeDir string, task *domain.Task, max int) ([]string, bool, error) {
+	var paths []string
+	truncated := false
+	err := filepath.WalkDir(homeDir, func(hostPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(homeDir, hostPath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == reservedDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !task.MatchArtifact(rel) {
+			return nil
+		}
+		if len(paths) >= max {
+			truncated = true
+			return filepath.SkipAll
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return paths, truncated, nil
+}
+
+// collectArtifacts reads artifacts of the task after phases. Large ones are put into the store.
+func collectArtifacts(homeDir string, task *domain.Task, limits ArtifactLimits, store *ArtifactStore) ([]*apiv1pb.Artifact, bool, error) {
+	limits = limits.withDefaults()
+
+	paths, truncated, err := findArtifacts(homeDir, task, limits.MaxArtifacts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	artifacts := make([]*apiv1pb.Artifact, 0, len(paths))
+	var total int64
+	for _, p := range paths {
+		artifact, err := readArtifact(homeDir, p, limits, &total, store)
+		if err != nil {
+			return nil, false, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, truncated, nil
+}
+
+func readArtifact(homeDir, p string, limits ArtifactLimits, total *int64, store *ArtifactStore) (*apiv1pb.Artifact, error) {
+	hostPath := filepath.Join(homeDir, filepath.FromSlash(p))
+	info, err := os.Lstat(hostPath)
+	if err != nil {
+		return nil, err
+	}
+	artifact := &apiv1pb.Artifact{
+		Path: p,
+		Size: info.Size(),
+	}
+	if !info.Mode().IsRegular() {
+		artifact.OmittedReason = "not a regular file"
+		return artifact, nil
+	}
+	if info.Size() > limits.MaxBytes {
+		artifact.OmittedReason = fmt.Sprintf("too large: %d bytes > %d bytes", info.Size(), limits.MaxBytes)
+		return artifact, nil
+	}
+	if *total+info.Size() > limits.MaxTotalBytes {
+		artifact.OmittedReason = fmt.Sprintf("artifacts are too large in total: > %d bytes", limits.MaxTotalBytes)
+		return artifact, nil
+	}
+
+	f, err := os.Open(hostPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	// The file must not be replaced after Lstat
+	if opened, err := f.Stat(); err != nil || !os.SameFile(info, opened) {
+		artifact.OmittedReason = "file changed"
+		return artifact, nil
+	}
+
+	content, err := io.ReadAll(io.LimitReader(f, info.Size()))
+	if err != nil {
+		return nil, err
+	}
+	artifact.Size = int64(len(content))
+	*total += artifact.Size
+
+	if artifact.Size <= limits.MaxInlineBytes {
+		artifact.Content = content
+		return artifact, nil
+	}
+	handle, err := store.Put(bytes.NewReader(content), artifact.Size)
+	if err != nil {
+		if errors.Is(err, errArtifactStoreFull) {
+			artifact.OmittedReason = "too many artifacts are stored on the server, try again later"
+			return artifact, nil
+		}
+		return nil, err
+	}
+	artifact.Handle = handle
+	return artifact, nil
+}
+
+// sendArtifacts sends artifacts of the task as the last events of the stream.
+func sendArtifacts(stream *connect.ServerStream[apiv1pb.RunOneshotResponse], homeDir string, task *domain.Task, limits ArtifactLimits, store *ArtifactStore) error {
+	artifacts, truncated, err := collectArtifacts(homeDir, task, limits, store)
+	if err != nil {
+		return err
+	}
+
+	if truncated {
+		warningVal := &apiv1pb.Warning{
+			Kind:    "artifacts_truncated",
+			Message: fmt.Sprintf("too many artifacts: only %d are returned", len(artifacts)),
+		}
+		if err := stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Warning{Warning: warningVal}}); err != nil {
+			return err
+		}
+	}
+	for _, artifact := range artifacts {
+		if err := stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Artifact{Artifact: artifact}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,431 @@
+package server
+
+import (
+	"archive/tar"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+const defaultBuildCacheMaxBytes = 1024 * 1024 * 1024 // 1GiB
+
+// Files of an entry in the cache directory
+const (
+	buildCacheHomeFile   = "home.tar"   // regular files in the home directory after the compile phase
+	buildCacheEventsFile = "events.bin" // delimited RunOneshotResponse of the compile phase
+)
+
+// BuildCache stores results of compile phases on disk keyed by hashes of inputs.
+// Least recently used entries are evicted when the total size exceeds MaxBytes.
+type BuildCache struct {
+	Dir      string
+	MaxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*buildCacheEntry // key -> entry
+	total   int64
+}
+
+type buildCacheEntry struct {
+	size     int64
+	lastUsed time.Time
+	pins     int // number of restores in progress, pinned entries are not evicted
+}
+
+// NewBuildCache creates the directory and indexes entries in it. Times of last use are restored from mtimes.
+func NewBuildCache(dir string, maxBytes int64) (*BuildCache, error) {
+	if maxBytes <= 0 {
+		maxBytes = defaultBuildCacheMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	c := &BuildCache{
+		Dir:      dir,
+		MaxBytes: maxBytes,
+		entries:  make(map[string]*buildCacheEntry),
+	}
+
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dirEntries {
+		if !d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			continue
+		}
+		size, lastUsed, err := buildCacheEntryStat(filepath.Join(dir, d.Name()))
+		if err != nil {
+			log.Printf("broken build cache entry: %s: %s", d.Name(), err)
+			os.RemoveAll(filepath.Join(dir, d.Name()))
+			continue
+		}
+		c.entries[d.Name()] = &buildCacheEntry{size: size, lastUsed: lastUsed}
+		c.total += size
+	}
+
+	c.mu.Lock()
+	c.evict()
+	c.mu.Unlock()
+
+	return c, nil
+}
+
+func buildCacheEntryStat(dir string) (int64, time.Time, error) {
+	var size int64
+	var lastUsed time.Time
+	for _, name := range []string{buildCacheHomeFile, buildCacheEventsFile} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		size += info.Size()
+		if info.ModTime().After(lastUsed) {
+			lastUsed = info.ModTime()
+		}
+	}
+	return size, lastUsed, nil
+}
+
+// buildCacheKey hashes everything which affects outputs of the compile phase.
+// The image is identified by the digest, so the key must not be made without it.
+func buildCacheKey(proc *domain.Processor, task *domain.Task, imageDigest string, cmd, env []string, limits string, files []*apiv1pb.File) string {
+	h := sha256.New()
+	write := func(field string, values ...string) {
+		fmt.Fprintf(h, "%s %d\n", field, len(values))
+		for _, v := range values {
+			fmt.Fprintf(h, "%d:%s\n", len(v), v)
+		}
+	}
+	write("processor", proc.ID)
+	write("task", task.ID)
+	write("image_digest", imageDigest)
+	write("cmd", cmd...)
+	write("env", env...) // sorted by buildEnv
+	write("limits", limits)
+
+	sorted := append([]*apiv1pb.File(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+	for _, f := range sorted {
+		sum := sha256.Sum256(f.Content)
+		write("file", f.Path, fmt.Sprint(f.Executable), hex.EncodeToString(sum[:]))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Restore writes files of the entry into the home directory and returns recorded events.
+// It reports false on misses. Files are extracted into a staging directory next to the home directory first,
+// so the home directory is left unchanged if the entry cannot be read.
+func (c *BuildCache) Restore(key, homeDir string, uid, gid int) ([]*apiv1pb.RunOneshotResponse, bool, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		entry.lastUsed = time.Now()
+		entry.pins++
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+	defer c.unpin(entry)
+
+	dir := filepath.Join(c.Dir, key)
+	now := time.Now()
+	if err := os.Chtimes(filepath.Join(dir, buildCacheEventsFile), now, now); err != nil {
+		return nil, false, err
+	}
+
+	events, err := readBuildCacheEvents(filepath.Join(dir, buildCacheEventsFile))
+	if err != nil {
+		return nil, false, err
+	}
+
+	stagingDir, err := os.MkdirTemp(filepath.Dir(homeDir), ".proclet-restore-")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := untarHome(filepath.Join(dir, buildCacheHomeFile), stagingDir, uid, gid); err != nil {
+		return nil, false, err
+	}
+	if err := moveHome(stagingDir, homeDir, uid, gid); err != nil {
+		return nil, false, err
+	}
+
+	return events, true, nil
+}
+
+// unpin releases the entry pinned by Restore and evicts entries which were kept for it.
+func (c *BuildCache) unpin(entry *buildCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.pins--
+	c.evict()
+}
+
+// Store saves regular files in the home directory and events of the compile phase.
+// Entries larger than a quarter of MaxBytes are not stored not to evict everything.
+func (c *BuildCache) Store(key, homeDir string, events []*apiv1pb.RunOneshotResponse) error {
+	c.mu.Lock()
+	_, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return nil // stored by a concurrent run
+	}
+
+	tmpDir, err := os.MkdirTemp(c.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	maxEntryBytes := c.MaxBytes / 4
+	if err := tarHome(homeDir, filepath.Join(tmpDir, buildCacheHomeFile), maxEntryBytes); err != nil {
+		if errors.Is(err, errBuildCacheEntryTooLarge) {
+			log.Printf("build cache skipped: %s", err)
+			return nil
+		}
+		return err
+	}
+	if err := writeBuildCacheEvents(filepath.Join(tmpDir, buildCacheEventsFile), events); err != nil {
+		return err
+	}
+	size, _, err := buildCacheEntryStat(tmpDir)
+	if err != nil {
+		return err
+	}
+	if size > maxEntryBytes {
+		log.Printf("build cache skipped: entry is too large: %d bytes", size)
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return nil
+	}
+	if err := os.Rename(tmpDir, filepath.Join(c.Dir, key)); err != nil {
+		return err
+	}
+	c.entries[key] = &buildCacheEntry{size: size, lastUsed: time.Now()}
+	c.total += size
+	c.evict()
+
+	return nil
+}
+
+// evict removes least recently used entries until the total size fits. c.mu must be held.
+// Pinned entries are skipped, and removed by a later call after they are unpinned.
+func (c *BuildCache) evict() {
+	for c.total > c.MaxBytes {
+		var oldestKey string
+		var oldest *buildCacheEntry
+		for key, entry := range c.entries {
+			if entry.pins > 0 {
+				continue
+			}
+			if oldest == nil || entry.lastUsed.Before(oldest.lastUsed) {
+				oldestKey, oldest = key, entry
+			}
+		}
+		if oldest == nil {
+			return
+		}
+
+		delete(c.entries, oldestKey)
+		c.total -= oldest.size
+		if err := os.RemoveAll(filepath.Join(c.Dir, oldestKey)); err != nil {
+			log.Printf("failed to remove build cache entry: %s", err)
+		}
+	}
+}
+
+var errBuildCacheEntryTooLarge = errors.New("build cache entry is too large")
+
+// tarHome archives regular files in the home directory. Links are skipped since they are made by users.
+func tarHome(homeDir, tarPath string, maxBytes int64) error {
+	f, err := os.OpenFile(tarPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	var total int64
+	err = filepath.WalkDir(homeDir, func(hostPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(homeDir, hostPath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel == reservedDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		if total > maxBytes {
+			return errors.Mark(errors.Newf("files are too large: > %d bytes", maxBytes), errBuildCacheEntryTooLarge)
+		}
+
+		src, err := os.Open(hostPath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     rel,
+			Mode:     int64(info.Mode().Perm()),
+			Size:     info.Size(),
+		}); err != nil {
+			return err
+		}
+		// The size must match the header even if the file has changed
+		_, err = io.Copy(tw, io.LimitReader(src, info.Size()))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// untarHome extracts files archived by tarHome into the directory with files owned by the runner.
+func untarHome(tarPath, homeDir string, uid, gid int) error {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(bufio.NewReader(f))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || !filepath.IsLocal(hdr.Name) {
+			return errors.Errorf("unexpected entry in build cache: '%s'", hdr.Name)
+		}
+
+		if err := mkdirAllOwned(homeDir, path.Dir(hdr.Name), uid, gid); err != nil {
+			return err
+		}
+		hostPath := filepath.Join(homeDir, filepath.FromSlash(hdr.Name))
+		dst, err := os.OpenFile(hostPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(dst, tr)
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		if err := os.Chmod(hostPath, os.FileMode(hdr.Mode).Perm()); err != nil {
+			return err
+		}
+		if err := os.Chown(hostPath, uid, gid); err != nil {
+			return err
+		}
+	}
+}
+
+// moveHome moves regular files extracted by untarHome into the home directory.
+// Files are renamed, so the staging directory must be on the same filesystem.
+func moveHome(stagingDir, homeDir string, uid, gid int) error {
+	return filepath.WalkDir(stagingDir, func(hostPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(stagingDir, hostPath)
+		if err != nil {
+			return err
+		}
+		if err := mkdirAllOwned(homeDir, path.Dir(filepath.ToSlash(rel)), uid, gid); err != nil {
+			return err
+		}
+		return os.Rename(hostPath, filepath.Join(homeDir, rel))
+	})
+}
+
+func writeBuildCacheEvents(p string, events []*apiv1pb.RunOneshotResponse) error {
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for _, event := range events {
+		if _, err := protodelim.MarshalTo(w, event); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func readBuildCacheEvents(p string) ([]*apiv1pb.RunOneshotResponse, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	var events []*apiv1pb.RunOneshotResponse
+	for {
+		event := &apiv1pb.RunOneshotResponse{}
+		if err := protodelim.UnmarshalFrom(r, event); err != nil {
+			if err == io.EOF {
+				return events, nil
+			}
+			return nil, err
+		}
+		events = append(events, event)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"go.uber.org/zap"
+)
+
+type errorInterceptor struct {
+	logger *zap.Logger
+}
+
+var _ connect.Interceptor = (*errorInterceptor)(nil)
+
+// NewErrorInterceptor maps errors returned by handlers to Connect codes with details.
+// Internal errors are logged as they are, and returned with host paths scrubbed.
+func NewErrorInterceptor(logger *zap.Logger) *errorInterceptor {
+	return &errorInterceptor{
+		logger: logger,
+	}
+}
+
+func (i *errorInterceptor) convert(procedure string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	connectErr, internal := toConnectError(err)
+	if internal {
+		i.logger.Error("Internal error", zap.String("Procedure", procedure), zap.Error(err))
+	}
+	return connectErr
+}
+
+func (i *errorInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		return res, i.convert(req.Spec().Procedure, err)
+	})
+}
+
+func (i *errorInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *errorInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(
+		ctx context.Context,
+		conn connect.StreamingHandlerConn,
+	) error {
+		return i.convert(conn.Spec().Procedure, next(ctx, conn))
+	})
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"regexp"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/errors"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// requestError is an error caused by a field of the request.
+// It is reported with the code and a FieldViolation detail naming the field.
+type requestError struct {
+	code  connect.Code
+	field string // e.g. "options[0].group_id"
+	err   error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+func invalidArgument(field string, format string, args ...interface{}) error {
+	return &requestError{code: connect.CodeInvalidArgument, field: field, err: errors.Errorf(format, args...)}
+}
+
+// resourceExhausted is for requests exceeding limits of sizes or counts.
+func resourceExhausted(field string, format string, args ...interface{}) error {
+	return &requestError{code: connect.CodeResourceExhausted, field: field, err: errors.Errorf(format, args...)}
+}
+
+func notFound(field string, format string, args ...interface{}) error {
+	return &requestError{code: connect.CodeNotFound, field: field, err: errors.Errorf(format, args...)}
+}
+
+func failedPrecondition(field string, format string, args ...interface{}) error {
+	return &requestError{code: connect.CodeFailedPrecondition, field: field, err: errors.Errorf(format, args...)}
+}
+
+// notFoundFields maps kinds of domain.NotFoundError to fields of requests.
+var notFoundFields = map[string]string{
+	domain.KindLanguage:  "language_id",
+	domain.KindProcessor: "processor_id",
+	domain.KindTask:      "task_id",
+}
+
+// toConnectError maps the error to a Connect code. Errors not caused by requests are Internal.
+// Messages are scrubbed not to leak host paths. The second value reports whether it is Internal.
+func toConnectError(err error) (*connect.Error, bool) {
+	var reqErr *requestError
+	var notFoundErr *domain.NotFoundError
+	var problems domain.ValidationErrors
+	var connectErr *connect.Error
+	switch {
+	case errors.As(err, &reqErr):
+		return newConnectError(reqErr.code, reqErr.Error(), &apiv1pb.FieldViolation{
+			Field:       reqErr.field,
+			Description: scrubPaths(reqErr.Error()),
+		}), false
+
+	case errors.As(err, &notFoundErr):
+		field := notFoundFields[notFoundErr.Kind]
+		if notFoundErr.ID == "" && notFoundErr.Kind == domain.KindLanguage {
+			field = "files" // detected from files
+		}
+		return newConnectError(connect.CodeNotFound, notFoundErr.Error(), &apiv1pb.FieldViolation{
+			Field:       field,
+			Description: notFoundErr.Error(),
+		}), false
+
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return newConnectError(connect.CodeAlreadyExists, err.Error()), false
+
+	case errors.As(err, &problems):
+		violations := make([]*apiv1pb.FieldViolation, 0, len(problems))
+		for _, p := range problems {
+			violations = append(violations, &apiv1pb.FieldViolation{
+				Field:       p.Path,
+				Description: p.Message,
+			})
+		}
+		return newConnectError(connect.CodeInvalidArgument, err.Error(), violations...), false
+
+	case errors.Is(err, context.Canceled):
+		return newConnectError(connect.CodeCanceled, "canceled"), false
+
+	case errors.Is(err, context.DeadlineExceeded):
+		return newConnectError(connect.CodeDeadlineExceeded, "deadline exceeded"), false
+
+	case errors.As(err, &connectErr):
+		switch connectErr.Code() {
+		case connect.CodeInternal, connect.CodeUnknown:
+			return newConnectError(connect.CodeInternal, connectErr.Message()), true
+		default:
+			return connectErr, false
+		}
+
+	default:
+		return newConnectError(connect.CodeInternal, err.Error()), true
+	}
+}
+
+func newConnectError(code connect.Code, message string, violations ...*apiv1pb.FieldViolation) *connect.Error {
+	connectErr := connect.NewError(code, errors.New(scrubPaths(message)))
+	for _, v := range violations {
+		detail, err := connect.NewErrorDetail(v)
+		if err != nil {
+			continue
+		}
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// absPathPattern matches absolute paths at the start of words. e.g. "/tmp/proclet-123/main.c"
+var absPathPattern = regexp.MustCompile(`(^|[\s'"(=:,])/+[^\s'"():,]+`)
+
+// scrubPaths replaces host paths in the message.
+func scrubPaths(message string) string {
+	return absPathPattern.ReplaceAllString(message, "$1<path>")
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// FileLimits limits files of a request. Zero values mean defaults.
+type FileLimits struct {
+	MaxFiles      int
+	MaxTotalBytes int64
+	MaxFileBytes  int64
+	MaxPathLength int // bytes
+	MaxPathDepth  int // number of path components
+}
+
+var DefaultFileLimits = FileLimits{
+	MaxFiles:      64,
+	MaxTotalBytes: 4 * 1024 * 1024, // 4MiB
+	MaxFileBytes:  1 * 1024 * 1024, // 1MiB
+	MaxPathLength: 256,
+	MaxPathDepth:  8,
+}
+
+func (l FileLimits) withDefaults() FileLimits {
+	if l.MaxFiles <= 0 {
+		l.MaxFiles = DefaultFileLimits.MaxFiles
+	}
+	if l.MaxTotalBytes <= 0 {
+		l.MaxTotalBytes = DefaultFileLimits.MaxTotalBytes
+	}
+	if l.MaxFileBytes <= 0 {
+		l.MaxFileBytes = DefaultFileLimits.MaxFileBytes
+	}
+	if l.MaxPathLength <= 0 {
+		l.MaxPathLength = DefaultFileLimits.MaxPathLength
+	}
+	if l.MaxPathDepth <= 0 {
+		l.MaxPathDepth = DefaultFileLimits.MaxPathDepth
+	}
+	return l
+}
+
+// reservedDir is the directory in the home directory for internal use.
+const reservedDir = ".proclet"
+
+// validateFiles checks counts, sizes and paths of the files.
+// Paths must be relative, clean, not reserved and not start with "-" in any component, and must not conflict with each other.
+func validateFiles(files []*apiv1pb.File, limits FileLimits) error {
+	return checkFiles(files, limits, func(i int, sub string) string {
+		if i < 0 {
+			return "files"
+		}
+		return fmt.Sprintf("files[%d]%s", i, sub)
+	})
+}
+
+// checkFiles is validateFiles naming fields by the function. i is -1 for the whole.
+func checkFiles(files []*apiv1pb.File, limits FileLimits, field func(i int, sub string) string) error {
+	limits = limits.withDefaults()
+
+	if len(files) > limits.MaxFiles {
+		return resourceExhausted(field(-1, ""), "too many files: %d > %d", len(files), limits.MaxFiles)
+	}
+
+	var total int64
+	paths := make(map[string]bool, len(files))
+	for i, f := range files {
+		if err := validateFilePath(f.Path, limits); err != nil {
+			return invalidArgument(field(i, ".path"), "%s", err)
+		}
+		if paths[f.Path] {
+			return invalidArgument(field(i, ".path"), "duplicated path: '%s'", f.Path)
+		}
+		paths[f.Path] = true
+
+		size := int64(len(f.Content))
+		if size > limits.MaxFileBytes {
+			return resourceExhausted(field(i, ".content"), "file is too large: '%s': %d bytes > %d bytes", f.Path, size, limits.MaxFileBytes)
+		}
+		total += size
+		if total > limits.MaxTotalBytes {
+			return resourceExhausted(field(-1, ""), "files are too large in total: > %d bytes", limits.MaxTotalBytes)
+		}
+	}
+
+	// A file cannot be a directory of another file
+	for i, f := range files {
+		for dir := path.Dir(f.Path); dir != "."; dir = path.Dir(dir) {
+			if paths[dir] {
+				return invalidArgument(field(i, ".path"), "parent is a file: '%s'", dir)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateFilePath(p string, limits FileLimits) error {
+	if p == "" {
+		return errors.New("path is empty")
+	}
+	if len(p) > limits.MaxPathLength {
+		return errors.Errorf("path is too long: %d bytes > %d bytes", len(p), limits.MaxPathLength)
+	}
+	if strings.HasPrefix(p, "/") {
+		return errors.Errorf("path must be relative: '%s'", p)
+	}
+	if strings.ContainsAny(p, "\\\x00") {
+		return errors.Errorf("path contains invalid characters: '%s'", p)
+	}
+
+	components := strings.Split(p, "/")
+	if len(components) > limits.MaxPathDepth {
+		return errors.Errorf("path is too deep: %d > %d", len(components), limits.MaxPathDepth)
+	}
+	for _, c := range components {
+		switch c {
+		case "", ".", "..":
+			return errors.Errorf("path must be clean: '%s'", p)
+		}
+		// Such names are taken as flags of commands
+		if strings.HasPrefix(c, "-") {
+			return errors.Errorf("path component must not start with '-': '%s'", p)
+		}
+	}
+	if components[0] == reservedDir {
+		return errors.Errorf("path is reserved: '%s'", p)
+	}
+
+	return nil
+}
+
+// writeFiles writes the validated files into the home directory. Parent directories are created as needed.
+func writeFiles(homeDir string, files []*apiv1pb.File, uid, gid int) error {
+	for _, f := range files {
+		if err := mkdirAllOwned(homeDir, path.Dir(f.Path), uid, gid); err != nil {
+			return err
+		}
+
+		var mode os.FileMode = 0644
+		if f.Executable {
+			mode = 0755
+		}
+		hostPath := filepath.Join(homeDir, filepath.FromSlash(f.Path))
+		if err := os.WriteFile(hostPath, f.Content, mode); err != nil {
+			return err
+		}
+		if err := os.Chown(hostPath, uid, gid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mkdirAllOwned creates the relative directory and its parents in the home directory owned by the runner.
+func mkdirAllOwned(homeDir, dir string, uid, gid int) error {
+	if dir == "." {
+		return nil
+	}
+	if err := mkdirAllOwned(homeDir, path.Dir(dir), uid, gid); err != nil {
+		return err
+	}
+
+	hostPath := filepath.Join(homeDir, filepath.FromSlash(dir))
+	if err := os.Mkdir(hostPath, 0755); err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil
+		}
+		return err
+	}
+	return os.Chown(hostPath, uid, gid)
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/yutopp/proclet/pkg/domain"
+)
+
+// ImageStates are availabilities and digests of images referenced in a profile.
+type ImageStates struct {
+	unavailable map[string]string // image -> reason
+	digests     map[string]string // image -> digest
+	ids         map[string]string // image -> image ID, only for images without digests
+}
+
+// UnavailableReason reports why the image cannot be used.
+func (s *ImageStates) UnavailableReason(image string) (string, bool) {
+	reason, ok := s.unavailable[image]
+	return reason, ok
+}
+
+// Digest returns the pinned digest of the processor, or the digest resolved when the image is prepared.
+func (s *ImageStates) Digest(proc *domain.Processor) string {
+	if proc.DockerImageDigest != "" {
+		return proc.DockerImageDigest
+	}
+	return s.digests[proc.ImageRef()]
+}
+
+// PinnedImage returns the reference of the processor's image pinned to the digest and the digest.
+// The content run by the reference is always the one the digest identifies, unlike tags which may be moved.
+// If the digest is unknown, the reference is not pinned and the digest is empty.
+func (s *ImageStates) PinnedImage(proc *domain.Processor) (string, string) {
+	ref := proc.ImageRef()
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		return ref, ref[i+1:]
+	}
+
+	digest := s.digests[ref]
+	if digest == "" {
+		return ref, ""
+	}
+	pinned := *proc
+	pinned.DockerImageDigest = digest
+	return pinned.ImageRef(), digest
+}
+
+// ContentKey returns the digest of the processor's image, or the image ID if the image has no digest like locally built ones.
+// It identifies the content of the image, and is empty if neither is known.
+func (s *ImageStates) ContentKey(proc *domain.Processor) string {
+	if digest := s.Digest(proc); digest != "" {
+		return digest
+	}
+	return s.ids[proc.ImageRef()]
+}
+
+func (s *ImageStates) known(image string) bool {
+	_, unavailable := s.unavailable[image]
+	_, available := s.digests[image]
+	return unavailable || available
+}
+
+// prepareImages resolves images referenced in the profile, and pulls missing ones if configured.
+// Unless full, states in prev are reused for images not referenced by changed processors.
+// It fails only if ctx is done, not to record cancellations as reasons of unavailability.
+func (s *Server) prepareImages(ctx context.Context, profile *domain.Profile, prev *Catalog, full bool) (*ImageStates, error) {
+	var touched map[string]bool
+	if !full && prev != nil {
+		touched = touchedImages(prev.Profile, profile)
+	}
+
+	states := &ImageStates{
+		unavailable: make(map[string]string),
+		digests:     make(map[string]string),
+		ids:         make(map[string]string),
+	}
+	for _, image := range profile.DockerImages() {
+		if touched != nil && !touched[image] && prev.Images.known(image) {
+			if reason, ok := prev.Images.unavailable[image]; ok {
+				states.unavailable[image] = reason
+			} else {
+				states.digests[image] = prev.Images.digests[image]
+				if id, ok := prev.Images.ids[image]; ok {
+					states.ids[image] = id
+				}
+			}
+			continue
+		}
+
+		if err := s.images.Ensure(ctx, image, s.config.PullImages); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.config.Logger.Warn("Image unavailable", zap.String("Image", image), zap.Error(err))
+			states.unavailable[image] = err.Error()
+			continue
+		}
+
+		digest, err := s.images.Digest(ctx, image)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			s.config.Logger.Warn("Image digest unresolved", zap.String("Image", image), zap.Error(err))
+
+			// Locally built images are identified by IDs instead
+			id, err := s.images.ID(ctx, image)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				s.config.Logger.Warn("Image ID unresolved", zap.String("Image", image), zap.Error(err))
+			}
+			if id != "" {
+				states.ids[image] = id
+			}
+		}
+		states.digests[image] = digest
+
+		s.config.Logger.Info("Image available", zap.String("Image", image), zap.String("Digest", digest))
+	}
+
+	return states, nil
+}
+
+// touchedImages returns images of processors which are added or changed.
+func touchedImages(old, new *domain.Profile) map[string]bool {
+	oldProcs := make(map[[2]string]*domain.Processor)
+	for i := range old.Languages {
+		l := &old.Languages[i]
+		for j := range l.Processors {
+			oldProcs[[2]string{l.ID, l.Processors[j].ID}] = &l.Processors[j]
+		}
+	}
+
+	touched := make(map[string]bool)
+	for i := range new.Languages {
+		l := &new.Languages[i]
+		for j := range l.Processors {
+			p := &l.Processors[j]
+			if prev, ok := oldProcs[[2]string{l.ID, p.ID}]; ok && reflect.DeepEqual(prev, p) {
+				continue
+			}
+			touched[p.ImageRef()] = true
+		}
+	}
+	return touched
+}
+
+// maxVersionDetections bounds version commands running at once not to starve runners.
+const maxVersionDetections = 4
+
+// detectVersions runs version commands of processors whose images are available in the background.
+// Versions already detected for the contents of images are skipped.
+func (s *Server) detectVersions(c *Catalog) {
+	seen := make(map[string]bool)
+	for _, l := range c.Profile.Languages {
+		for i := range l.Processors {
+			p := &l.Processors[i]
+			if len(p.VersionCmd) == 0 {
+				continue
+			}
+			if _, ok := c.Images.UnavailableReason(p.ImageRef()); ok {
+				continue
+			}
+			contentKey := c.Images.ContentKey(p)
+			if _, ok := s.versions.Cached(contentKey, p.VersionCmd); ok {
+				continue
+			}
+			// Processors sharing the image and the command report the same version
+			key := p.ImageRef() + "\x00" + versionCacheKey(contentKey, p.VersionCmd)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			go s.detectVersion(l.ID, p, contentKey)
+		}
+	}
+}
+
+func (s *Server) detectVersion(langID string, p *domain.Processor, contentKey string) {
+	select {
+	case s.versionSlots <- struct{}{}:
+		defer func() { <-s.versionSlots }()
+	case <-s.ctx.Done():
+		return
+	}
+
+	version, err := s.versions.Detect(s.ctx, p.ImageRef(), contentKey, p.VersionCmd)
+	if err != nil {
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.config.Logger.Warn("Version detection failed", zap.String("Language", langID), zap.String("Processor", p.ID), zap.Error(err))
+		return
+	}
+	s.config.Logger.Info("Version detected", zap.String("Language", langID), zap.String("Processor", p.ID), zap.String("Version", version))
+}
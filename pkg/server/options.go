@@ -0,0 +1,117 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+const (
+	maxOptionValues = 32   // per group
+	maxRunArgs      = 64   // per request
+	maxArgLength    = 1024 // bytes
+)
+
+// resolveOptions validates options chosen by the user and returns arguments in the order of groups in the profile.
+// Default choices are used for groups not chosen.
+func resolveOptions(task *domain.Task, selected []*apiv1pb.SelectedOption) ([]string, error) {
+	byGroup := make(map[string]int, len(selected)) // group id -> index in the request
+	for i, sel := range selected {
+		if _, ok := byGroup[sel.GroupId]; ok {
+			return nil, invalidArgument(fmt.Sprintf("options[%d].group_id", i), "option group is duplicated: '%s'", sel.GroupId)
+		}
+		if findOptionGroup(task, sel.GroupId) == nil {
+			return nil, invalidArgument(fmt.Sprintf("options[%d].group_id", i), "option group not found: '%s'", sel.GroupId)
+		}
+		byGroup[sel.GroupId] = i
+	}
+
+	var args []string
+	for i := range task.OptionGroups {
+		group := &task.OptionGroups[i]
+
+		choiceIDs := group.Default
+		var values []string
+		field := ""
+		if j, ok := byGroup[group.ID]; ok {
+			choiceIDs = selected[j].ChoiceIds
+			values = selected[j].Values
+			field = fmt.Sprintf("options[%d]", j)
+		}
+		// Defaults are validated with the profile, so invalid ones are not faults of requests
+		choiceError := func(sub string, format string, args ...interface{}) error {
+			if field == "" {
+				return errors.Wrapf(errors.Newf(format, args...), "invalid default of option group '%s'", group.ID)
+			}
+			return invalidArgument(field+sub, format, args...)
+		}
+
+		if len(choiceIDs) > 1 && !group.Multiple {
+			return nil, choiceError(".choice_ids", "option group '%s' accepts only one choice", group.ID)
+		}
+		for k, id := range choiceIDs {
+			choice := findOptionChoice(group, id)
+			if choice == nil {
+				return nil, choiceError(fmt.Sprintf(".choice_ids[%d]", k), "option choice not found: '%s' in '%s'", id, group.ID)
+			}
+			args = append(args, choice.Args...)
+		}
+
+		if len(values) > 0 && !group.FreeForm {
+			return nil, invalidArgument(field+".values", "option group '%s' does not accept free-form options", group.ID)
+		}
+		if len(values) > maxOptionValues {
+			return nil, resourceExhausted(field+".values", "too many options in '%s': %d > %d", group.ID, len(values), maxOptionValues)
+		}
+		for k, value := range values {
+			if len(value) > maxArgLength {
+				return nil, resourceExhausted(fmt.Sprintf("%s.values[%d]", field, k), "option is too long: %d bytes > %d bytes", len(value), maxArgLength)
+			}
+			if !group.Match(value) {
+				return nil, invalidArgument(fmt.Sprintf("%s.values[%d]", field, k), "option is not allowed in '%s': '%s'", group.ID, value)
+			}
+			args = append(args, value)
+		}
+	}
+
+	return args, nil
+}
+
+func validateRunArgs(task *domain.Task, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	if !task.AllowRunArgs || task.Run == nil {
+		return invalidArgument("run_args", "task does not accept program arguments: '%s'", task.ID)
+	}
+	if len(args) > maxRunArgs {
+		return resourceExhausted("run_args", "too many program arguments: %d > %d", len(args), maxRunArgs)
+	}
+	for i, arg := range args {
+		if len(arg) > maxArgLength {
+			return resourceExhausted(fmt.Sprintf("run_args[%d]", i), "program argument is too long: %d bytes > %d bytes", len(arg), maxArgLength)
+		}
+	}
+	return nil
+}
+
+func findOptionGroup(task *domain.Task, id string) *domain.OptionGroup {
+	for i := range task.OptionGroups {
+		if task.OptionGroups[i].ID == id {
+			return &task.OptionGroups[i]
+		}
+	}
+	return nil
+}
+
+func findOptionChoice(group *domain.OptionGroup, id string) *domain.OptionChoice {
+	for i := range group.Choices {
+		if group.Choices[i].ID == id {
+			return &group.Choices[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"path"
+	"strings"
+
+	"github.com/yutopp/proclet/pkg/domain"
+	apiv1pb "github.com/yutopp/proclet/pkg/proto/api/v1"
+)
+
+// Placeholders in phase commands expanded per request.
+const (
+	placeholderSource  = "{source}"  // primary source file
+	placeholderSources = "{sources}" // all source files, spliced as separate arguments
+	placeholderOutput  = "{output}"  // output binary path
+	placeholderOptions = "{options}" // user-supplied options, spliced as separate arguments
+	placeholderArgs    = "{args}"    // user-supplied program arguments, spliced as separate arguments
+)
+
+type placeholders struct {
+	Source  string
+	Sources []string
+	Output  string
+	Options []string
+	Args    []string
+}
+
+// newPlaceholders builds values of placeholders from the request files.
+// The primary source file is the one named as DefaultFilename, or the first file.
+// Paths are made not to start with "-" not to be taken as flags of commands.
+func newPlaceholders(proc *domain.Processor, files []*apiv1pb.File) *placeholders {
+	p := &placeholders{
+		Source: proc.DefaultFilename,
+	}
+
+	for i, file := range files {
+		filePath := cleanFilePath(file.Path)
+		p.Sources = append(p.Sources, argPath(filePath))
+		if i == 0 || filePath == proc.DefaultFilename {
+			p.Source = filePath
+		}
+	}
+	if len(p.Sources) == 0 && p.Source != "" {
+		p.Sources = []string{argPath(p.Source)}
+	}
+
+	p.Output = proc.OutputFilename
+	if p.Output == "" {
+		p.Output = strings.TrimSuffix(path.Base(p.Source), path.Ext(p.Source))
+	}
+	p.Source = argPath(p.Source)
+	p.Output = argPath(p.Output)
+
+	return p
+}
+
+// cleanFilePath returns the path relative to the home directory.
+func cleanFilePath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+filePath), "/")
+}
+
+// argPath prefixes "./" to the relative path if it starts with "-".
+func argPath(filePath string) string {
+	if strings.HasPrefix(filePath, "-") {
+		return "./" + filePath
+	}
+	return filePath
+}
+
+// expand replaces placeholders in the command.
+// List placeholders are spliced when they are whole arguments, otherwise joined by spaces.
+func (p *placeholders) expand(cmd []string) []string {
+	expanded := make([]string, 0, len(cmd))
+	for _, arg := range cmd {
+		switch arg {
+		case placeholderSources:
+			expanded = append(expanded, p.Sources...)
+			continue
+		case placeholderOptions:
+			expanded = append(expanded, p.Options...)
+			continue
+		case placeholderArgs:
+			expanded = append(expanded, p.Args...)
+			continue
+		}
+
+		arg = strings.NewReplacer(
+			placeholderSource, p.Source,
+			placeholderSources, strings.Join(p.Sources, " "),
+			placeholderOutput, p.Output,
+			placeholderOptions, strings.Join(p.Options, " "),
+			placeholderArgs, strings.Join(p.Args, " "),
+		).Replace(arg)
+		expanded = append(expanded, arg)
+	}
+
+	return expanded
+}
+
+// expandShell expands the command into a script of "/bin/sh -c" by joining arguments with spaces.
+// Arguments are kept as written in the profile to use operators and variables like "&&" and "$HOME",
+// and only values of placeholders are quoted. Program arguments are appended if appendArgs and {args} is not in the command.
+func (p *placeholders) expandShell(cmd []string, appendArgs bool) string {
+	quoteAll := func(values []string) string {
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, shellQuote(v))
+		}
+		return strings.Join(quoted, " ")
+	}
+	replacer := strings.NewReplacer(
+		placeholderSource, shellQuote(p.Source),
+		placeholderSources, quoteAll(p.Sources),
+		placeholderOutput, shellQuote(p.Output),
+		placeholderOptions, quoteAll(p.Options),
+		placeholderArgs, quoteAll(p.Args),
+	)
+
+	expanded := make([]string, 0, len(cmd)+1)
+	hasArgs := false
+	for _, arg := range cmd {
+		if strings.Contains(arg, placeholderArgs) {
+			hasArgs = true
+		}
+		expanded = append(expanded, replacer.Replace(arg))
+	}
+	if appendArgs && !hasArgs && len(p.Args) > 0 {
+		expanded = append(expanded, quoteAll(p.Args))
+	}
+
+	return strings.Join(expanded, " ")
+}
+
+// expandRun expands the run command. Program arguments are appended if {args} is not in the command.
+func (p *placeholders) expandRun(cmd []string) []string {
+	expanded := p.expand(cmd)
+	for _, arg := range cmd {
+		if strings.Contains(arg, placeholderArgs) {
+			return expanded
+		}
+	}
+	return append(expanded, p.Args...)
+}
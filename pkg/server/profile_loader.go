@@ -3,14 +3,24 @@ package server
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"gopkg.in/yaml.v3"
 
 	"github.com/yutopp/proclet/pkg/domain"
 )
 
+// ProfileFromFile loads and saves the profile from a JSON or YAML file, or a directory.
+// A directory holds per-language files (*.json, *.yaml, *.yml) which are merged into one profile in the order of file names.
 type ProfileFromFile struct {
 	Path string
+
+	// languageFiles remembers files of languages loaded from the directory to save them back.
+	languageFiles map[string]string // language id -> file name
 }
 
 func NewProfileFromFile(path string) *ProfileFromFile {
@@ -19,29 +29,230 @@ func NewProfileFromFile(path string) *ProfileFromFile {
 	}
 }
 
+func (p *ProfileFromFile) isDir() bool {
+	info, err := os.Stat(p.Path)
+	return err == nil && info.IsDir()
+}
+
 func (p *ProfileFromFile) Load() (*domain.Profile, error) {
-	r, err := os.Open(p.Path)
+	if p.isDir() {
+		return p.loadDir()
+	}
+
+	var profile *domain.Profile
+	if err := decodeFile(p.Path, &profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// LoadExpanded loads the profile, expands templates and validates it. The result is for serving, not for saving.
+func (p *ProfileFromFile) LoadExpanded() (*domain.Profile, error) {
+	raw, err := p.Load()
 	if err != nil {
 		return nil, err
 	}
-	defer r.Close()
+	return ExpandProfile(raw)
+}
 
-	var profile *domain.Profile
-	if err := json.NewDecoder(r).Decode(&profile); err != nil {
+// ExpandProfile expands templates of the profile as written and validates it.
+// Every path which serves or checks profiles must use it to run the same checks.
+func ExpandProfile(raw *domain.Profile) (*domain.Profile, error) {
+	if raw == nil {
+		return nil, errors.New("profile is empty")
+	}
+
+	profile, err := raw.Expand()
+	if err != nil {
+		return nil, err
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (p *ProfileFromFile) loadDir() (*domain.Profile, error) {
+	names, err := p.dirFiles()
+	if err != nil {
 		return nil, err
 	}
 
+	profile := &domain.Profile{}
+	languageFiles := make(map[string]string)
+	for _, name := range names {
+		var lang domain.Language
+		if err := decodeFile(filepath.Join(p.Path, name), &lang); err != nil {
+			return nil, err
+		}
+		languageFiles[lang.ID] = name
+		profile.Languages = append(profile.Languages, lang)
+	}
+	p.languageFiles = languageFiles
+
 	return profile, nil
 }
 
+// dirFiles returns the sorted names of profile files in the directory.
+func (p *ProfileFromFile) dirFiles() ([]string, error) {
+	entries, err := os.ReadDir(p.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read directory: %s", p.Path)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		switch filepath.Ext(e.Name()) {
+		case ".json", ".yaml", ".yml":
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Stamp returns the latest modification time and the total size of the profile files to detect changes.
+func (p *ProfileFromFile) Stamp() (time.Time, int64, error) {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if !info.IsDir() {
+		return info.ModTime(), info.Size(), nil
+	}
+
+	// Directory mtime changes when files are added or removed
+	modTime, size := info.ModTime(), int64(0)
+	names, err := p.dirFiles()
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(p.Path, name))
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		size += info.Size()
+	}
+
+	return modTime, size, nil
+}
+
 func (p *ProfileFromFile) Save(profile *domain.Profile) error {
-	w, err := os.Create(p.Path)
+	if p.isDir() {
+		return p.saveDir(profile)
+	}
+
+	return encodeFile(p.Path, profile)
+}
+
+func (p *ProfileFromFile) saveDir(profile *domain.Profile) error {
+	if p.languageFiles == nil {
+		// Index existing files not to duplicate languages
+		if _, err := p.loadDir(); err != nil {
+			return err
+		}
+	}
+
+	languageFiles := make(map[string]string)
+	written := make(map[string]bool)
+	for _, lang := range profile.Languages {
+		name, ok := p.languageFiles[lang.ID]
+		if !ok {
+			name = lang.ID + ".yaml"
+		}
+		// IDs are validated, but file names must never escape the directory
+		if !filepath.IsLocal(name) || filepath.Base(name) != name {
+			return errors.Newf("invalid file name for language '%s': %s", lang.ID, name)
+		}
+		if err := encodeFile(filepath.Join(p.Path, name), &lang); err != nil {
+			return err
+		}
+		languageFiles[lang.ID] = name
+		written[name] = true
+	}
+
+	// Remove files of languages which are removed from the profile
+	for _, name := range p.languageFiles {
+		if written[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(p.Path, name)); err != nil {
+			return errors.Wrapf(err, "failed to remove file: %s", name)
+		}
+	}
+	p.languageFiles = languageFiles
+
+	return nil
+}
+
+// LoadProcessorFile loads a processor definition from a JSON or YAML file to import it into profiles.
+func LoadProcessorFile(path string) (*domain.Processor, error) {
+	var proc domain.Processor
+	if err := decodeFile(path, &proc); err != nil {
+		return nil, err
+	}
+	if proc.ID == "" {
+		return nil, errors.Newf("processor id is empty: %s", path)
+	}
+
+	return &proc, nil
+}
+
+func isYAML(path string) bool {
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+func decodeFile(path string, v interface{}) error {
+	b, err := os.ReadFile(path)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create file: %s", p.Path)
+		return err
+	}
+
+	if isYAML(path) {
+		if err := yaml.Unmarshal(b, v); err != nil {
+			return errors.Wrapf(err, "failed to decode YAML: %s", path)
+		}
+		return nil
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrapf(err, "failed to decode JSON: %s", path)
+	}
+	return nil
+}
+
+func encodeFile(path string, v interface{}) error {
+	w, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file: %s", path)
 	}
 	defer w.Close()
 
-	if err := json.NewEncoder(w).Encode(profile); err != nil {
+	if isYAML(path) {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
 		return err
 	}
 
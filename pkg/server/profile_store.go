@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/yutopp/proclet/pkg/domain"
+)
+
+// Catalog is a snapshot of the profile served together with states of its images.
+type Catalog struct {
+	Raw     *domain.Profile // as written, templates are not expanded
+	Profile *domain.Profile // expanded and validated
+	Images  *ImageStates
+}
+
+// PrepareFunc prepares images of the candidate profile before it is served.
+// prev is the current catalog or nil. If full is false, states of images not affected by the change may be reused.
+type PrepareFunc func(ctx context.Context, profile *domain.Profile, prev *Catalog, full bool) (*ImageStates, error)
+
+// ProfileStore holds the loaded profile in memory as an atomic snapshot.
+// The snapshot is replaced only if a newly loaded profile is valid and its images are prepared,
+// so a malformed edit never breaks the traffic.
+type ProfileStore struct {
+	repo    *ProfileFromFile
+	prepare PrepareFunc
+	logger  *zap.Logger
+
+	current atomic.Pointer[Catalog]
+
+	mu      sync.Mutex // serializes loads, saves and preparations
+	modTime time.Time
+	size    int64
+}
+
+func NewProfileStore(repo *ProfileFromFile, prepare PrepareFunc, logger *zap.Logger) *ProfileStore {
+	return &ProfileStore{
+		repo:    repo,
+		prepare: prepare,
+		logger:  logger,
+	}
+}
+
+// Get returns the current profile. It must not be modified.
+func (s *ProfileStore) Get() *domain.Profile {
+	if c := s.current.Load(); c != nil {
+		return c.Profile
+	}
+	return nil
+}
+
+// Catalog returns the current snapshot. It must not be modified.
+func (s *ProfileStore) Catalog() *Catalog {
+	return s.current.Load()
+}
+
+// Reload loads the profile from the file, prepares every image, and swaps the snapshot if it is valid.
+// If it is invalid, the previous snapshot is kept and the error is returned.
+func (s *ProfileStore) Reload(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modTime, size, err := s.repo.Stamp()
+	if err != nil {
+		s.logger.Error("Profile reload failed", zap.String("Path", s.repo.Path), zap.Error(err))
+		return err
+	}
+	s.modTime = modTime
+	s.size = size
+
+	raw, err := s.repo.Load()
+	if err != nil {
+		s.logger.Error("Profile reload failed, keeping the previous profile", zap.String("Path", s.repo.Path), zap.Error(err))
+		return err
+	}
+	if err := s.swap(ctx, raw, true); err != nil {
+		s.logger.Error("Profile reload failed, keeping the previous profile", zap.String("Path", s.repo.Path), zap.Error(err))
+		return err
+	}
+	s.logger.Info("Profile reloaded", zap.String("Path", s.repo.Path), zap.Int("Languages", len(raw.Languages)))
+
+	return nil
+}
+
+// Update applies the edit to the profile as written in the file, then saves it and swaps the snapshot.
+// Only images affected by the edit are prepared.
+// If the edited profile is invalid, neither the file nor the snapshot is changed.
+func (s *ProfileStore) Update(ctx context.Context, edit func(profile *domain.Profile) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Edit the latest file not to discard changes made outside
+	raw, err := s.repo.Load()
+	if err != nil {
+		return err
+	}
+	if raw == nil {
+		raw = &domain.Profile{}
+	}
+	if err := edit(raw); err != nil {
+		return err
+	}
+
+	profile, err := ExpandProfile(raw)
+	if err != nil {
+		return err
+	}
+	images, err := s.prepare(ctx, profile, s.current.Load(), false)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Save(raw); err != nil {
+		return err
+	}
+	// Saved files are already reflected
+	if modTime, size, err := s.repo.Stamp(); err == nil {
+		s.modTime = modTime
+		s.size = size
+	}
+
+	s.current.Store(&Catalog{Raw: raw, Profile: profile, Images: images})
+	s.logger.Info("Profile updated", zap.String("Path", s.repo.Path), zap.Int("Languages", len(profile.Languages)))
+
+	return nil
+}
+
+// swap expands the raw profile by ExpandProfile, prepares images and stores the snapshot. s.mu must be held.
+func (s *ProfileStore) swap(ctx context.Context, raw *domain.Profile, full bool) error {
+	profile, err := ExpandProfile(raw)
+	if err != nil {
+		return err
+	}
+	images, err := s.prepare(ctx, profile, s.current.Load(), full)
+	if err != nil {
+		return err
+	}
+
+	s.current.Store(&Catalog{Raw: raw, Profile: profile, Images: images})
+	return nil
+}
+
+// changed reports whether the file has been modified since the last reload.
+func (s *ProfileStore) changed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modTime, size, err := s.repo.Stamp()
+	if err != nil {
+		return false
+	}
+	return !modTime.Equal(s.modTime) || size != s.size
+}
+
+// Watch polls the file and reloads the profile on change until ctx is done.
+// onReload is called after a successful reload.
+func (s *ProfileStore) Watch(ctx context.Context, interval time.Duration, onReload func()) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-t.C:
+			if !s.changed() {
+				continue
+			}
+			if err := s.Reload(ctx); err != nil {
+				continue
+			}
+			if onReload != nil {
+				onReload()
+			}
+		}
+	}
+}
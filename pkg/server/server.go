@@ -2,17 +2,22 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
-	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/yutopp/proclet/pkg/domain"
@@ -24,72 +29,203 @@ import (
 
 type Config struct {
 	ProfilePath string
+	// PullImages pulls missing images when the profile is loaded
+	PullImages bool
+	// RedactCommands hides commands of tasks from List
+	RedactCommands bool
+	// AdminToken is the bearer token of AdminService. If empty, AdminService is not served.
+	AdminToken string
 
 	TempDir   string
 	RunnerUID int
 	RunnerGID int
 
+	// CPUs are cores dedicated to runners. If empty, all cores are used.
+	CPUs []int
+
+	// FileLimits limits files of a request
+	FileLimits FileLimits
+	// ArtifactLimits limits artifacts returned by a run
+	ArtifactLimits ArtifactLimits
+	// ArtifactTTL is how long large artifacts can be downloaded
+	ArtifactTTL time.Duration
+	// ArtifactStoreMaxBytes caps the total size of large artifacts kept for downloads
+	ArtifactStoreMaxBytes int64
+	// BuildCache skips compile phases whose inputs are seen before. If nil, every run compiles.
+	BuildCache *BuildCache
+
 	Logger *zap.Logger
 }
 
 // Server implements the RunnerServiceHandler interface
 type Server struct {
-	config *Config
+	config       *Config
+	profiles     *ProfileStore
+	cpuScheduler *container.CPUScheduler
+	images       *container.DockerImages
+	versions     *VersionDetector
+	artifacts    *ArtifactStore
+
+	// ctx is done when the server is closed. Background work runs on it instead of request contexts.
+	ctx          context.Context
+	cancel       context.CancelFunc
+	versionSlots chan struct{}
 }
 
 var _ apiv1connect.RunnerServiceHandler = (*Server)(nil)
 
 func Register(mux *http.ServeMux, srv *Server) {
 	loggingInterceptor := NewLoggingInterceptor(srv.config.Logger)
-	path, handler := apiv1connect.NewRunnerServiceHandler(srv, connect.WithInterceptors(loggingInterceptor))
+	errorInterceptor := NewErrorInterceptor(srv.config.Logger)
+	path, handler := apiv1connect.NewRunnerServiceHandler(srv, connect.WithInterceptors(loggingInterceptor, errorInterceptor))
 	mux.Handle(path, handler)
 }
 
 func NewServer(c *Config) *Server {
-	return &Server{
-		config: c,
+	cpus := c.CPUs
+	if len(cpus) == 0 {
+		for i := 0; i < runtime.NumCPU(); i++ {
+			cpus = append(cpus, i)
+		}
+	}
+
+	cpuScheduler := container.NewCPUScheduler(cpus)
+	versions := NewVersionDetector(c.TempDir, c.RunnerUID, c.RunnerGID)
+	versions.CPUScheduler = cpuScheduler
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		config:       c,
+		cpuScheduler: cpuScheduler,
+		images:       container.NewDockerImages(),
+		versions:     versions,
+		artifacts:    NewArtifactStore(c.TempDir, c.ArtifactTTL, c.ArtifactStoreMaxBytes),
+		ctx:          ctx,
+		cancel:       cancel,
+		versionSlots: make(chan struct{}, maxVersionDetections),
 	}
+	s.profiles = NewProfileStore(NewProfileFromFile(c.ProfilePath), s.prepareImages, c.Logger)
+	go s.artifacts.SweepEvery(ctx, artifactSweepInterval)
+
+	return s
 }
 
-func (s *Server) List(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListResponse], error) {
-	profileRepo := NewProfileFromFile(s.config.ProfilePath)
-	profile, err := profileRepo.Load()
-	if err != nil {
-		return nil, err
+// Close stops background work of the server and removes artifacts kept for downloads.
+func (s *Server) Close() {
+	s.cancel()
+	if err := s.artifacts.Close(); err != nil {
+		s.config.Logger.Warn("Failed to remove artifacts", zap.Error(err))
 	}
+}
+
+// LoadProfile loads the profile and prepares images referenced in it before serving it.
+// Versions are detected in the background.
+func (s *Server) LoadProfile(ctx context.Context) error {
+	if err := s.profiles.Reload(ctx); err != nil {
+		return err
+	}
+	s.detectVersions(s.profiles.Catalog())
+
+	return nil
+}
+
+// WatchProfile reloads the profile on file change until ctx is done.
+func (s *Server) WatchProfile(ctx context.Context) {
+	s.profiles.Watch(ctx, 2*time.Second, func() {
+		s.detectVersions(s.profiles.Catalog())
+	})
+}
+
+func (s *Server) List(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.ListResponse], error) {
+	catalog := s.profiles.Catalog()
+	profile := catalog.Profile
 
 	res := &v1.ListResponse{
 		Languages: make([]*apiv1pb.Language, 0, len(profile.Languages)),
 	}
 	for _, l := range profile.Languages {
+		if l.Disabled {
+			continue
+		}
 		lang := &apiv1pb.Language{
 			Id:       l.ID,
 			ShowName: l.ShowName,
+
+			Description:    l.Description,
+			FileExtensions: l.FileExtensions,
+
+			Aliases:  l.Aliases,
+			Shebangs: l.Shebangs,
+		}
+		if p, err := l.PreferredProcessor(); err == nil {
+			lang.DefaultProcessorId = p.ID
 		}
 		for _, p := range l.Processors {
 			proc := &apiv1pb.Processor{
-				Id:       p.ID,
-				ShowName: p.ShowName,
+				Id:          p.ID,
+				ShowName:    p.ShowName,
+				Description: p.Description,
+				Version:     p.Version,
 
 				DefaultFilename: p.DefaultFilename,
+				SampleCode:      p.SampleCode,
+
+				DockerImage: p.DockerImage,
+				ImageDigest: catalog.Images.Digest(&p),
+
+				Lifecycle: lifecycleToPB(&p.Lifecycle),
+			}
+			if t, err := p.PreferredTask(); err == nil {
+				proc.DefaultTaskId = t.ID
+			}
+			if version, ok := s.versions.Cached(catalog.Images.ContentKey(&p), p.VersionCmd); ok {
+				proc.DetectedVersion = version
+			}
+			if p.IsDisabled() {
+				proc.Disabled = true
+				proc.DisabledReason = p.StatusMessage
+			} else if reason, ok := catalog.Images.UnavailableReason(p.ImageRef()); ok {
+				proc.Disabled = true
+				proc.DisabledReason = reason
 			}
 			for _, t := range p.Tasks {
 				task := &apiv1pb.Task{
-					Id:       t.ID,
-					ShowName: t.ShowName,
+					Id:          t.ID,
+					ShowName:    t.ShowName,
+					Description: t.Description,
 
 					Kind: t.Kind,
+
+					Lifecycle: lifecycleToPB(&t.Lifecycle),
 				}
 				if t.Compile != nil {
-					task.Compile = &apiv1pb.PhasedTask{
-						// Cmd: t.Compile.Cmd,
-					}
+					task.Compile = s.phasedTaskToPB(t.Compile)
 				}
 				if t.Run != nil {
-					task.Run = &apiv1pb.PhasedTask{
-						// Cmd: t.Run.Cmd,
+					task.Run = s.phasedTaskToPB(t.Run)
+				}
+				for _, g := range t.OptionGroups {
+					group := &apiv1pb.OptionGroup{
+						Id:       g.ID,
+						ShowName: g.ShowName,
+
+						DefaultChoiceIds: g.Default,
+						Multiple:         g.Multiple,
+
+						FreeForm: g.FreeForm,
+						Safelist: g.Safelist,
+					}
+					for _, c := range g.Choices {
+						group.Choices = append(group.Choices, &apiv1pb.OptionChoice{
+							Id:       c.ID,
+							ShowName: c.ShowName,
+							Args:     c.Args,
+						})
 					}
+					task.OptionGroups = append(task.OptionGroups, group)
 				}
+				task.AllowRunArgs = t.AllowRunArgs
+				task.Artifacts = t.Artifacts
 
 				proc.Tasks = append(proc.Tasks, task)
 			}
@@ -105,53 +241,199 @@ func (s *Server) List(context.Context, *connect.Request[emptypb.Empty]) (*connec
 	}, nil
 }
 
+func lifecycleToPB(l *domain.Lifecycle) *apiv1pb.Lifecycle {
+	status := l.Status
+	if status == "" {
+		status = domain.StatusActive
+	}
+	return &apiv1pb.Lifecycle{
+		Status:     status,
+		Message:    l.StatusMessage,
+		ReplacedBy: l.ReplacedBy,
+	}
+}
+
+func (s *Server) phasedTaskToPB(phase *domain.PhasedTask) *apiv1pb.PhasedTask {
+	limits, cores := buildResourceLimits(phase.Limits)
+
+	phaseVal := &apiv1pb.PhasedTask{
+		Shell: phase.Shell,
+		Limits: &apiv1pb.Limits{
+			CpuTime:    limits.CPUTime,
+			WallTime:   int64(limits.EffectiveWallTime() / time.Second),
+			Memory:     limits.Memory,
+			MemorySwap: limits.MemorySwap,
+			CpuQuota:   limits.CPUQuota,
+			CpuPeriod:  limits.CPUPeriod,
+			Cores:      int64(cores),
+		},
+	}
+	if s.config.RedactCommands {
+		phaseVal.CmdRedacted = true
+	} else {
+		phaseVal.Cmd = phase.Cmd
+	}
+
+	return phaseVal
+}
+
+func (s *Server) Resolve(ctx context.Context, req *connect.Request[apiv1pb.ResolveRequest]) (*connect.Response[apiv1pb.ResolveResponse], error) {
+	files, err := requestFiles(req.Msg.Files, req.Msg.Archive, s.config.FileLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution, err := resolve(s.profiles.Get(), req.Msg.LanguageId, req.Msg.ProcessorId, req.Msg.TaskId, files)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&apiv1pb.ResolveResponse{
+		Resolution: resolutionToPB(resolution),
+	}), nil
+}
+
+func (s *Server) DownloadArtifact(
+	ctx context.Context,
+	req *connect.Request[apiv1pb.DownloadArtifactRequest],
+	stream *connect.ServerStream[apiv1pb.DownloadArtifactResponse],
+) error {
+	f, ok, err := s.artifacts.Open(req.Msg.Handle)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound("handle", "artifact not found or expired")
+	}
+	defer f.Close()
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&apiv1pb.DownloadArtifactResponse{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func (s *Server) RunOneshot(
 	ctx context.Context,
 	req *connect.Request[apiv1pb.RunOneshotRequest],
 	stream *connect.ServerStream[apiv1pb.RunOneshotResponse],
 ) error {
-	_, proc, task, err := s.lookupLanguage(req.Msg.LanguageId, req.Msg.ProcessorId, req.Msg.TaskId)
+	files, err := requestFiles(req.Msg.Files, req.Msg.Archive, s.config.FileLimits)
+	if err != nil {
+		return err
+	}
+
+	catalog := s.profiles.Catalog()
+	resolution, err := resolve(catalog.Profile, req.Msg.LanguageId, req.Msg.ProcessorId, req.Msg.TaskId, files)
+	if err != nil {
+		return err
+	}
+	lang, proc, task := resolution.Language, resolution.Processor, resolution.Task
+	if lang.Disabled {
+		return failedPrecondition("language_id", "language is disabled: '%s'", lang.ID)
+	}
+	if proc.IsDisabled() {
+		return failedPrecondition("processor_id", "processor is disabled: '%s'%s", proc.ID, lifecycleDetail(&proc.Lifecycle))
+	}
+	if task.IsDisabled() {
+		return failedPrecondition("task_id", "task is disabled: '%s'%s", task.ID, lifecycleDetail(&task.Lifecycle))
+	}
+	if reason, ok := catalog.Images.UnavailableReason(proc.ImageRef()); ok {
+		return failedPrecondition("processor_id", "processor is unavailable: '%s': %s", proc.ID, reason)
+	}
+	if err := validateUserEnv(task, req.Msg.Env); err != nil {
+		return err
+	}
+	options, err := resolveOptions(task, req.Msg.Options)
 	if err != nil {
 		return err
 	}
+	if err := validateRunArgs(task, req.Msg.RunArgs); err != nil {
+		return err
+	}
+	placeholders := newPlaceholders(proc, files)
+	placeholders.Options = options
+	placeholders.Args = req.Msg.RunArgs
 
 	dirName, err := os.MkdirTemp(s.config.TempDir, "proclet-")
 	if err != nil {
 		return err
 	}
+	// Artifacts handed out are copied into the ArtifactStore by sendArtifacts
+	defer func() {
+		if err := os.RemoveAll(dirName); err != nil {
+			log.Printf("failed to remove directory: %s", err)
+		}
+	}()
 	if err := os.Chown(dirName, s.config.RunnerUID, s.config.RunnerGID); err != nil {
 		return err
 	}
 	log.Printf("directory created: %s", dirName)
 
-	for _, file := range req.Msg.Files {
-		path := filepath.Join(dirName, filepath.Clean(filepath.Join("/", file.Path)))
-		if err := os.WriteFile(path, file.Content, 0755); err != nil {
-			return err
-		}
-		if err := os.Chown(path, s.config.RunnerUID, s.config.RunnerGID); err != nil {
-			return err
-		}
+	if err := writeFiles(dirName, files, s.config.RunnerUID, s.config.RunnerGID); err != nil {
+		return err
 	}
 
+	image, digest := proc.ImageRef(), catalog.Images.Digest(proc)
+	if s.config.BuildCache != nil {
+		// Cached outputs must be of the content identified by the digest
+		image, digest = catalog.Images.PinnedImage(proc)
+	}
 	c := &executeConfig{
-		Image:    proc.DockerImage,
-		ShellCmd: "",
+		Image:       image,
+		ImageDigest: digest,
 
 		RunnerUID: s.config.RunnerUID,
 		RunnerGID: s.config.RunnerGID,
 		DirName:   dirName,
 
+		Placeholders: placeholders,
+		UserEnv:      req.Msg.Env,
+
+		CPUScheduler: s.cpuScheduler,
+
 		Stream: stream,
 	}
+	resolutionVal := resolutionToPB(resolution)
+	if err := stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Resolution{Resolution: resolutionVal}}); err != nil {
+		return err
+	}
+	if proc.IsDeprecated() {
+		if err := sendDeprecationWarning(stream, fmt.Sprintf("processor '%s' is deprecated", proc.ID), &proc.Lifecycle); err != nil {
+			return err
+		}
+	}
+	if task.IsDeprecated() {
+		if err := sendDeprecationWarning(stream, fmt.Sprintf("task '%s' is deprecated", task.ID), &task.Lifecycle); err != nil {
+			return err
+		}
+	}
+
 	if task.Compile != nil {
-		if err := executePhase(ctx, c, task.Compile); err != nil {
+		if err := s.compile(ctx, c, proc, task, files); err != nil {
 			return err
 		}
 	}
 
 	if task.Run != nil {
-		if err := executePhase(ctx, c, task.Run); err != nil {
+		if err := executePhase(ctx, c, "run", task.Run); err != nil {
+			return err
+		}
+	}
+
+	if len(task.Artifacts) > 0 && ctx.Err() == nil {
+		if err := sendArtifacts(stream, dirName, task, s.config.ArtifactLimits, s.artifacts); err != nil {
 			return err
 		}
 	}
@@ -161,39 +443,199 @@ func (s *Server) RunOneshot(
 	return nil
 }
 
+// compile executes the compile phase, or replays it from the build cache on hits.
+// Only phases exited normally are cached since limits exceeded depend on the load of the host.
+// Phases are cached only if the image is run by the digest, see ImageStates.PinnedImage.
+func (s *Server) compile(ctx context.Context, c *executeConfig, proc *domain.Processor, task *domain.Task, files []*apiv1pb.File) error {
+	cache := s.config.BuildCache
+	if cache == nil || c.ImageDigest == "" {
+		return executePhase(ctx, c, "compile", task.Compile)
+	}
+
+	resourceLimit, cores := buildResourceLimits(task.Compile.Limits)
+	key := buildCacheKey(
+		proc, task, c.ImageDigest,
+		buildCmd("compile", task.Compile, c.Placeholders),
+		buildEnv(task.Compile, c.UserEnv),
+		fmt.Sprintf("%+v cores=%d", resourceLimit, cores),
+		files,
+	)
+
+	events, ok, err := cache.Restore(key, c.DirName, c.RunnerUID, c.RunnerGID)
+	if err != nil {
+		log.Printf("build cache restore failed: %s", err)
+	} else if ok {
+		log.Printf("build cache hit: %s", key)
+		for _, event := range events {
+			if result := event.GetResult(); result != nil {
+				result.Cached = true
+			}
+			if err := c.Stream.Send(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	c.Record = true
+	err = executePhase(ctx, c, "compile", task.Compile)
+	events = c.Recorded
+	c.Record, c.Recorded = false, nil
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil || len(events) == 0 {
+		return nil
+	}
+	if result := events[len(events)-1].GetResult(); result == nil || result.Reason != "" {
+		return nil
+	}
+	if err := cache.Store(key, c.DirName, events); err != nil {
+		log.Printf("build cache store failed: %s", err)
+	}
+	return nil
+}
+
+// lifecycleDetail formats the message and the replacement to append to errors.
+func lifecycleDetail(l *domain.Lifecycle) string {
+	var detail string
+	if l.StatusMessage != "" {
+		detail += ": " + l.StatusMessage
+	}
+	if l.ReplacedBy != "" {
+		detail += fmt.Sprintf(" (use '%s' instead)", l.ReplacedBy)
+	}
+	return detail
+}
+
+func sendDeprecationWarning(stream *connect.ServerStream[apiv1pb.RunOneshotResponse], message string, l *domain.Lifecycle) error {
+	warningVal := &apiv1pb.Warning{
+		Kind:       domain.StatusDeprecated,
+		Message:    message + lifecycleDetail(l),
+		ReplacedBy: l.ReplacedBy,
+	}
+	return stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Warning{Warning: warningVal}})
+}
+
 type executeConfig struct {
-	Image    string
-	ShellCmd string
+	Image       string
+	ImageDigest string
 
 	RunnerUID int
 	RunnerGID int
 	DirName   string
 
+	Placeholders *placeholders
+	UserEnv      map[string]string
+
+	CPUScheduler *container.CPUScheduler
+
 	Stream *connect.ServerStream[apiv1pb.RunOneshotResponse]
+	// Recorded holds sent events if Record is set, to be cached
+	Record   bool
+	Recorded []*apiv1pb.RunOneshotResponse
 }
 
-func buildShellCmd(cmd []string) string {
-	// TODO: escape
-	return strings.Join(cmd, " ")
+// send sends the event to the stream and records it if needed.
+func (c *executeConfig) send(res *apiv1pb.RunOneshotResponse) error {
+	if c.Record {
+		// Buffers of outputs are reused by redirect
+		c.Recorded = append(c.Recorded, proto.Clone(res).(*apiv1pb.RunOneshotResponse))
+	}
+	return c.Stream.Send(res)
+}
+
+// buildCmd returns the argument vector of the container with placeholders expanded.
+// Cmd is executed directly by default, and via /bin/sh only if the shell form is requested.
+func buildCmd(phaseName string, phase *domain.PhasedTask, p *placeholders) []string {
+	if phase.Shell {
+		return []string{"/bin/sh", "-c", p.expandShell(phase.Cmd, phaseName == "run")}
+	}
+	if phaseName == "run" {
+		return p.expandRun(phase.Cmd)
+	}
+	return p.expand(phase.Cmd)
+}
+
+// validateUserEnv checks that every variable is allowed by at least one phase of the task.
+func validateUserEnv(task *domain.Task, env map[string]string) error {
+	for name := range env {
+		if !domain.IsValidEnvName(name) {
+			return invalidArgument("env", "invalid name of environment variable: '%s'", name)
+		}
+		allowed := false
+		for _, phase := range []*domain.PhasedTask{task.Compile, task.Run} {
+			if phase != nil && isEnvAllowed(phase, name) {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return invalidArgument(fmt.Sprintf("env[%s]", name), "environment variable is not allowed: '%s'", name)
+		}
+	}
+	return nil
+}
+
+func isEnvAllowed(phase *domain.PhasedTask, name string) bool {
+	for _, n := range phase.AllowedEnv {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// buildEnv merges fixed variables of the phase and allowed variables from the user, which take precedence.
+func buildEnv(phase *domain.PhasedTask, userEnv map[string]string) []string {
+	env := make(map[string]string, len(phase.Env)+len(userEnv))
+	for k, v := range phase.Env {
+		env[k] = v
+	}
+	for k, v := range userEnv {
+		if isEnvAllowed(phase, k) {
+			env[k] = v
+		}
+	}
+
+	kvs := make([]string, 0, len(env))
+	for k, v := range env {
+		kvs = append(kvs, k+"="+v)
+	}
+	sort.Strings(kvs)
+
+	return kvs
+}
+
+var shellSafePattern = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes the argument by single quotes for POSIX shells.
+func shellQuote(arg string) string {
+	if shellSafePattern.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
 }
 
-func executePhase(ctx context.Context, c *executeConfig, phase *domain.PhasedTask) error {
+func executePhase(ctx context.Context, c *executeConfig, phaseName string, phase *domain.PhasedTask) error {
 	e := container.NewDockerRunner()
 
+	resourceLimit, cores := buildResourceLimits(phase.Limits)
+
+	// Pin the phase to dedicated cores not to be disturbed by concurrent runs
+	alloc, err := c.CPUScheduler.Acquire(ctx, cores)
+	if err != nil {
+		return err
+	}
+	defer alloc.Release()
+	resourceLimit.CPUSet = alloc.CPUSet()
+
 	stdoutR, stdoutW := io.Pipe()
 	stderrR, stderrW := io.Pipe()
-	resourceLimit := container.ResourceLimits{
-		Core:    0,                // Process can NOT create CORE file
-		Nofile:  512,              // Process can open 512 files
-		NProc:   30,               // Process can create processes to 30
-		MemLock: 1024,             // Process can lock 1024 Bytes by mlock(2)
-		CPUTime: 5,                // sec
-		Memory:  10 * 1024 * 1024, // bytes
-		FSize:   5 * 1024 * 1024,  // Process can writes a file only 5MiB
-	}
 	containerTask := &container.RunTask{
-		Image:    c.Image,
-		ShellCmd: buildShellCmd(phase.Cmd),
+		Image: c.Image,
+		Cmd:   buildCmd(phaseName, phase, c.Placeholders),
+		Env:   buildEnv(phase, c.UserEnv),
 
 		UID:         c.RunnerUID,
 		GID:         c.RunnerGID,
@@ -221,7 +663,7 @@ func executePhase(ctx context.Context, c *executeConfig, phase *domain.PhasedTas
 			Kind:   0, // stdout
 			Buffer: buf,
 		}
-		if err := c.Stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Output{Output: outVal}}); err != nil {
+		if err := c.send(&apiv1pb.RunOneshotResponse{Phase: phaseName, Response: &apiv1pb.RunOneshotResponse_Output{Output: outVal}}); err != nil {
 			return err
 		}
 
@@ -235,7 +677,7 @@ func executePhase(ctx context.Context, c *executeConfig, phase *domain.PhasedTas
 			Kind:   1, // stderr
 			Buffer: buf,
 		}
-		if err := c.Stream.Send(&apiv1pb.RunOneshotResponse{Response: &apiv1pb.RunOneshotResponse_Output{Output: outVal}}); err != nil {
+		if err := c.send(&apiv1pb.RunOneshotResponse{Phase: phaseName, Response: &apiv1pb.RunOneshotResponse_Output{Output: outVal}}); err != nil {
 			return err
 		}
 
@@ -253,11 +695,76 @@ func executePhase(ctx context.Context, c *executeConfig, phase *domain.PhasedTas
 			return nil
 		}
 		log.Printf("done: %+v", out)
+		if out.Err != nil {
+			return out.Err
+		}
+
+		resultVal := &apiv1pb.Result{
+			ExitCode:    out.ExitCode,
+			Reason:      string(out.Reason),
+			Signal:      out.Signal,
+			MemoryLimit: out.MemoryLimit,
+
+			ImageDigest: c.ImageDigest,
+		}
+		if err := c.send(&apiv1pb.RunOneshotResponse{Phase: phaseName, Response: &apiv1pb.RunOneshotResponse_Result{Result: resultVal}}); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func buildResourceLimits(l *domain.Limits) (container.ResourceLimits, int) {
+	resourceLimit := container.ResourceLimits{
+		Core:    0,                // Process can NOT create CORE file
+		Nofile:  512,              // Process can open 512 files
+		NProc:   30,               // Process can create processes to 30
+		MemLock: 1024,             // Process can lock 1024 Bytes by mlock(2)
+		CPUTime: 5,                // sec
+		Memory:  10 * 1024 * 1024, // bytes
+		FSize:   5 * 1024 * 1024,  // Process can writes a file only 5MiB
+
+		WallTime: 0, // sec, CPUTime + extension by default
+
+		MemorySwap: 0,      // bytes, same as Memory by default (= no swap)
+		CPUQuota:   0,      // microsec, CPUPeriod * cores by default
+		CPUPeriod:  100000, // microsec
+	}
+	cores := 1
+	if l != nil {
+		if l.CPUTime > 0 {
+			resourceLimit.CPUTime = l.CPUTime
+		}
+		if l.WallTime > 0 {
+			resourceLimit.WallTime = l.WallTime
+		}
+		if l.Memory > 0 {
+			resourceLimit.Memory = l.Memory
+		}
+		if l.MemorySwap != 0 {
+			resourceLimit.MemorySwap = l.MemorySwap
+		}
+		if l.CPUQuota > 0 {
+			resourceLimit.CPUQuota = l.CPUQuota
+		}
+		if l.CPUPeriod > 0 {
+			resourceLimit.CPUPeriod = l.CPUPeriod
+		}
+		if l.Cores > 0 {
+			cores = l.Cores
+		}
+	}
+	if resourceLimit.MemorySwap == 0 {
+		resourceLimit.MemorySwap = resourceLimit.Memory
+	}
+	if resourceLimit.CPUQuota == 0 {
+		resourceLimit.CPUQuota = resourceLimit.CPUPeriod * int64(cores)
+	}
+
+	return resourceLimit, cores
+}
+
 func redirect(ctx context.Context, wg *sync.WaitGroup, pipe *io.PipeReader, callback func([]byte) error) {
 	defer wg.Done()
 
@@ -283,45 +790,24 @@ func redirect(ctx context.Context, wg *sync.WaitGroup, pipe *io.PipeReader, call
 	}
 }
 
-func (s *Server) lookupLanguage(languageID, processorID, taskID string) (*domain.Language, *domain.Processor, *domain.Task, error) {
-	profileRepo := NewProfileFromFile(s.config.ProfilePath)
-	profile, err := profileRepo.Load()
-	if err != nil {
-		return nil, nil, nil, err
+// resolve finds the language, the processor and the task of the request.
+// The language can be an alias or detected from files, and empty IDs of the processor and the task select defaults.
+func resolve(profile *domain.Profile, languageID, processorID, taskID string, files []*apiv1pb.File) (*domain.Resolution, error) {
+	sourceFiles := make([]domain.SourceFile, 0, len(files))
+	for _, f := range files {
+		sourceFiles = append(sourceFiles, domain.SourceFile{Path: f.Path, Content: f.Content})
 	}
 
-	var lang *domain.Language
-	for _, l := range profile.Languages {
-		if l.ID == languageID {
-			lang = &l
-			break
-		}
-	}
-	if lang == nil {
-		return nil, nil, nil, errors.Errorf("language not found: '%s'", languageID)
-	}
+	return profile.Resolve(languageID, processorID, taskID, sourceFiles)
+}
 
-	var proc *domain.Processor
-	for _, p := range lang.Processors {
-		if p.ID == processorID {
-			proc = &p
-			break
-		}
-	}
-	if proc == nil {
-		return nil, nil, nil, errors.Errorf("processor not found: '%s'", processorID)
-	}
+func resolutionToPB(r *domain.Resolution) *apiv1pb.Resolution {
+	return &apiv1pb.Resolution{
+		LanguageId:  r.Language.ID,
+		ProcessorId: r.Processor.ID,
+		TaskId:      r.Task.ID,
 
-	var task *domain.Task
-	for _, t := range proc.Tasks {
-		if t.ID == taskID {
-			task = &t
-			break
-		}
-	}
-	if task == nil {
-		return nil, nil, nil, errors.Errorf("task not found: '%s'", taskID)
+		MatchedBy:   r.MatchedBy,
+		MatchedFile: r.MatchedFile,
 	}
-
-	return lang, proc, task, nil
 }
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/yutopp/proclet/pkg/service/container"
+)
+
+const (
+	maxVersionLength = 256
+	// maxVersionOutput caps the captured output. The rest is drained not to block the command.
+	maxVersionOutput = 4 * 1024
+)
+
+// VersionDetector runs version commands of processors in their images.
+// Outputs are cached by keys of image contents, digests or image IDs, since the same content always reports the same version.
+type VersionDetector struct {
+	TempDir   string
+	RunnerUID int
+	RunnerGID int
+
+	// CPUScheduler pins commands to dedicated cores if set.
+	CPUScheduler *container.CPUScheduler
+
+	mu       sync.RWMutex
+	versions map[string]string // content key + cmd -> version
+}
+
+func NewVersionDetector(tempDir string, uid, gid int) *VersionDetector {
+	return &VersionDetector{
+		TempDir:   tempDir,
+		RunnerUID: uid,
+		RunnerGID: gid,
+		versions:  make(map[string]string),
+	}
+}
+
+func versionCacheKey(contentKey string, cmd []string) string {
+	return contentKey + "\x00" + strings.Join(cmd, "\x00")
+}
+
+// Cached returns the version detected before without running the command.
+func (d *VersionDetector) Cached(contentKey string, cmd []string) (string, bool) {
+	if contentKey == "" || len(cmd) == 0 {
+		return "", false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	version, ok := d.versions[versionCacheKey(contentKey, cmd)]
+	return version, ok
+}
+
+// Detect returns the first non-empty line of the output of cmd in the image.
+// contentKey identifies the content of the image, see ImageStates.ContentKey.
+// If it is empty, the result is not cached since the image may change.
+func (d *VersionDetector) Detect(ctx context.Context, image, contentKey string, cmd []string) (string, error) {
+	if version, ok := d.Cached(contentKey, cmd); ok {
+		return version, nil
+	}
+
+	output, err := d.run(ctx, image, cmd)
+	if err != nil {
+		return "", err
+	}
+	version := firstLine(output)
+
+	if contentKey != "" {
+		d.mu.Lock()
+		d.versions[versionCacheKey(contentKey, cmd)] = version
+		d.mu.Unlock()
+	}
+
+	return version, nil
+}
+
+func (d *VersionDetector) run(ctx context.Context, image string, cmd []string) ([]byte, error) {
+	dirName, err := os.MkdirTemp(d.TempDir, "proclet-version-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dirName)
+	if err := os.Chown(dirName, d.RunnerUID, d.RunnerGID); err != nil {
+		return nil, err
+	}
+
+	resourceLimit, cores := buildResourceLimits(nil)
+	resourceLimit.Memory = 256 * 1024 * 1024 // some runtimes like JVM need more memory to start
+	resourceLimit.MemorySwap = resourceLimit.Memory
+	if d.CPUScheduler != nil {
+		alloc, err := d.CPUScheduler.Acquire(ctx, cores)
+		if err != nil {
+			return nil, err
+		}
+		defer alloc.Release()
+		resourceLimit.CPUSet = alloc.CPUSet()
+	}
+
+	// Some commands like `java -version` print to stderr
+	var mu sync.Mutex
+	var output bytes.Buffer
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+	var ioWg sync.WaitGroup
+	ioWg.Add(2) // stdout, stderr
+	for _, r := range []*io.PipeReader{stdoutR, stderrR} {
+		go redirect(ctx, &ioWg, r, func(buf []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if n := maxVersionOutput - output.Len(); len(buf) > n {
+				buf = buf[:n]
+			}
+			output.Write(buf)
+			return nil
+		})
+	}
+
+	handle, err := container.NewDockerRunner().Run(ctx, &container.RunTask{
+		Image: image,
+		Cmd:   cmd,
+
+		UID:         d.RunnerUID,
+		GID:         d.RunnerGID,
+		HomeHostDir: dirName,
+
+		Stdout: stdoutW,
+		Stderr: stderrW,
+
+		Limits: resourceLimit,
+	})
+	if err != nil {
+		stdoutW.Close()
+		stderrW.Close()
+		return nil, err
+	}
+	ioWg.Wait()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case out, ok := <-handle.DoneCh:
+		if !ok {
+			return nil, errors.New("version command was not finished")
+		}
+		if out.Err != nil {
+			return nil, out.Err
+		}
+		if out.ExitCode != 0 || out.Reason != container.ReasonNone {
+			return nil, errors.Errorf("version command failed: exit code %d %s: %s", out.ExitCode, out.Reason, firstLine(output.Bytes()))
+		}
+	}
+
+	return output.Bytes(), nil
+}
+
+func firstLine(output []byte) string {
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) > maxVersionLength {
+			line = line[:maxVersionLength]
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package container
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// CPUScheduler assigns dedicated CPU cores to concurrent runs so that timing results are reproducible.
+type CPUScheduler struct {
+	cpus []int
+
+	mu         sync.Mutex
+	used       []bool
+	releasedCh chan struct{} // closed and replaced when cores are released
+}
+
+type CPUAllocation struct {
+	CPUs []int
+
+	scheduler *CPUScheduler
+	once      sync.Once
+}
+
+func NewCPUScheduler(cpus []int) *CPUScheduler {
+	return &CPUScheduler{
+		cpus:       cpus,
+		used:       make([]bool, len(cpus)),
+		releasedCh: make(chan struct{}),
+	}
+}
+
+// Acquire blocks until n cores are free or ctx is done.
+func (s *CPUScheduler) Acquire(ctx context.Context, n int) (*CPUAllocation, error) {
+	if n <= 0 {
+		n = 1
+	}
+	if n > len(s.cpus) {
+		return nil, errors.Errorf("requested %d cores but only %d cores are schedulable", n, len(s.cpus))
+	}
+
+	for {
+		s.mu.Lock()
+		if cpus := s.tryAllocate(n); cpus != nil {
+			s.mu.Unlock()
+			return &CPUAllocation{
+				CPUs:      cpus,
+				scheduler: s,
+			}, nil
+		}
+		releasedCh := s.releasedCh
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-releasedCh:
+		}
+	}
+}
+
+func (s *CPUScheduler) tryAllocate(n int) []int {
+	indices := make([]int, 0, n)
+	for i, used := range s.used {
+		if !used {
+			indices = append(indices, i)
+		}
+		if len(indices) == n {
+			break
+		}
+	}
+	if len(indices) < n {
+		return nil
+	}
+
+	cpus := make([]int, 0, n)
+	for _, i := range indices {
+		s.used[i] = true
+		cpus = append(cpus, s.cpus[i])
+	}
+	return cpus
+}
+
+func (s *CPUScheduler) release(cpus []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cpu := range cpus {
+		for i, c := range s.cpus {
+			if c == cpu {
+				s.used[i] = false
+			}
+		}
+	}
+	close(s.releasedCh)
+	s.releasedCh = make(chan struct{})
+}
+
+// Release returns the cores to the scheduler. It is safe to call multiple times.
+func (a *CPUAllocation) Release() {
+	a.once.Do(func() {
+		a.scheduler.release(a.CPUs)
+	})
+}
+
+// CPUSet returns the cores in the cpuset format of cgroups. e.g. "0,1"
+func (a *CPUAllocation) CPUSet() string {
+	ss := make([]string, 0, len(a.CPUs))
+	for _, cpu := range a.CPUs {
+		ss = append(ss, strconv.Itoa(cpu))
+	}
+	return strings.Join(ss, ",")
+}
+
+// ParseCPUSet parses the cpuset format of cgroups. e.g. "0-3,6"
+func ParseCPUSet(s string) ([]int, error) {
+	var cpus []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		lo, hi, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid cpuset: %s", s)
+		}
+		last := first
+		if isRange {
+			last, err = strconv.Atoi(hi)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid cpuset: %s", s)
+			}
+		}
+		if first < 0 || last < first {
+			return nil, errors.Errorf("invalid cpuset: %s", s)
+		}
+
+		for cpu := first; cpu <= last; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	if len(cpus) == 0 {
+		return nil, errors.Errorf("empty cpuset: %s", s)
+	}
+
+	return cpus, nil
+}
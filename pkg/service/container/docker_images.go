@@ -0,0 +1,132 @@
+package container
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/cockroachdb/errors"
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+type DockerImages struct {
+}
+
+func NewDockerImages() *DockerImages {
+	return &DockerImages{}
+}
+
+// Exists reports whether the image is present locally.
+func (d *DockerImages) Exists(ctx context.Context, image string) (bool, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create docker client")
+	}
+	defer cli.Close()
+
+	_, _, err = cli.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to inspect image: %s", image)
+	}
+
+	return true, nil
+}
+
+// Pull pulls the image from the registry and waits until it completes.
+func (d *DockerImages) Pull(ctx context.Context, image string) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return errors.Wrap(err, "failed to create docker client")
+	}
+	defer cli.Close()
+
+	log.Printf("pull: %s", image)
+
+	r, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to pull image: %s", image)
+	}
+	defer r.Close()
+
+	// Progress messages must be consumed until the end to complete pulling.
+	// Failures like missing manifests are reported in the stream, not by ImagePull.
+	if err := jsonmessage.DisplayJSONMessagesStream(r, io.Discard, 0, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to pull image: %s", image)
+	}
+
+	return nil
+}
+
+// Ensure makes the image available locally. If pull is false, only the existence is checked.
+func (d *DockerImages) Ensure(ctx context.Context, image string, pull bool) error {
+	exists, err := d.Exists(ctx, image)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	if !pull {
+		return errors.Errorf("image not found: %s", image)
+	}
+
+	return d.Pull(ctx, image)
+}
+
+// Digest returns the content digest of the local image. e.g. "sha256:..."
+func (d *DockerImages) Digest(ctx context.Context, image string) (string, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create docker client")
+	}
+	defer cli.Close()
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid image reference: %s", image)
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		return digested.Digest().String(), nil
+	}
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to inspect image: %s", image)
+	}
+
+	// Images may be tagged in multiple repositories, so pick the digest of the same repository
+	for _, repoDigest := range inspect.RepoDigests {
+		repoNamed, err := reference.ParseNormalizedNamed(repoDigest)
+		if err != nil {
+			continue
+		}
+		digested, ok := repoNamed.(reference.Digested)
+		if !ok || repoNamed.Name() != named.Name() {
+			continue
+		}
+		return digested.Digest().String(), nil
+	}
+
+	return "", errors.Errorf("digest not found (image may be built locally): %s", image)
+}
+
+// ID returns the ID of the local image, which identifies its content even if the image has no digest.
+func (d *DockerImages) ID(ctx context.Context, image string) (string, error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create docker client")
+	}
+	defer cli.Close()
+
+	inspect, _, err := cli.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to inspect image: %s", image)
+	}
+	return inspect.ID, nil
+}
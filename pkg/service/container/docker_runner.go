@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"runtime"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -21,8 +24,9 @@ type DockerRunner struct {
 }
 
 type RunTask struct {
-	Image    string
-	ShellCmd string
+	Image string
+	Cmd   []string // exec form
+	Env   []string // "KEY=value"
 
 	UID         int
 	GID         int
@@ -43,6 +47,25 @@ type ResourceLimits struct {
 	CPUTime int64 // sec
 	Memory  int64 // bytes
 	FSize   int64
+
+	// WallTime is a realtime limit apart from CPUTime to kill processes blocking on I/O or sleeping.
+	// If zero, CPUTime + defaultWallTimeExtension is used.
+	WallTime int64 // sec
+
+	MemorySwap int64  // bytes, memory + swap. -1 = unlimited swap
+	CPUQuota   int64  // microsec per CPUPeriod
+	CPUPeriod  int64  // microsec
+	CPUSet     string // cores to pin processes to. e.g. "0,1"
+}
+
+const defaultWallTimeExtension = 3 // sec
+
+// EffectiveWallTime returns WallTime, or the default derived from CPUTime.
+func (l *ResourceLimits) EffectiveWallTime() time.Duration {
+	if l.WallTime > 0 {
+		return time.Duration(l.WallTime) * time.Second
+	}
+	return time.Duration(l.CPUTime+defaultWallTimeExtension) * time.Second
 }
 
 func NewDockerRunner() *DockerRunner {
@@ -50,7 +73,47 @@ func NewDockerRunner() *DockerRunner {
 }
 
 type Handle struct {
-	DoneCh chan interface{}
+	DoneCh chan *Result
+}
+
+type Reason string
+
+const (
+	ReasonNone             Reason = ""
+	ReasonCPUTimeExceeded  Reason = "cpu_time_exceeded"
+	ReasonWallTimeExceeded Reason = "wall_time_exceeded"
+	ReasonMemoryExceeded   Reason = "memory_limit_exceeded"
+	ReasonSignaled         Reason = "signaled"
+)
+
+type Result struct {
+	ExitCode int64
+	Reason   Reason
+	Signal   int64 // set if Reason is ReasonSignaled
+
+	MemoryLimit int64 // bytes, set if Reason is ReasonMemoryExceeded
+
+	Err error
+}
+
+// Exit codes of processes killed by signals. e.g. 128 + SIGKILL
+const (
+	exitCodeSignalBase = 128
+	exitCodeSIGKILL    = exitCodeSignalBase + 9
+	exitCodeSIGXCPU    = exitCodeSignalBase + 24
+
+	maxSignal = 64 // SIGRTMAX on Linux
+)
+
+// signalOf returns the signal which the exit code reports as 128 + the signal number.
+// Docker reports only exit codes, so processes exiting with such codes by themselves (e.g. exit(130))
+// cannot be distinguished from signaled ones. Codes out of the range of signals are not signals.
+func signalOf(exitCode int64) (int64, bool) {
+	signal := exitCode - exitCodeSignalBase
+	if signal < 1 || signal > maxSignal {
+		return 0, false
+	}
+	return signal, true
 }
 
 func makeULimit(name string, lim int64) *units.Ulimit {
@@ -71,17 +134,22 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 
 	containerHomeDir := "/home/proclet"
 	hostConfig := &container.HostConfig{
-		AutoRemove:     true,
+		AutoRemove:     false, // Removed after inspecting the state
 		ReadonlyRootfs: true,
 		Privileged:     false,
 		Resources: container.Resources{
-			Memory: task.Limits.Memory, // bytes
+			Memory:     task.Limits.Memory,     // bytes
+			MemorySwap: task.Limits.MemorySwap, // bytes
+			CPUQuota:   task.Limits.CPUQuota,
+			CPUPeriod:  task.Limits.CPUPeriod,
+			CpusetCpus: task.Limits.CPUSet,
 			Ulimits: []*units.Ulimit{
 				makeULimit("core", task.Limits.Core),
 				makeULimit("nofile", task.Limits.Nofile),
 				makeULimit("nproc", task.Limits.NProc), // NOTE: per-user limit
 				makeULimit("memlock", task.Limits.MemLock),
-				makeULimit("cpu", task.Limits.CPUTime),
+				// Soft limit sends SIGXCPU, then hard limit sends SIGKILL
+				{Name: "cpu", Soft: task.Limits.CPUTime, Hard: task.Limits.CPUTime + 1},
 				// makeULimit("as", task.Limits.Memory), disabled by docker
 				makeULimit("fsize", task.Limits.FSize),
 			},
@@ -99,7 +167,8 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 	stopTimeout := 3 // sec
 	resp, err := cli.ContainerCreate(ctx, &container.Config{
 		Image:       task.Image,
-		Cmd:         []string{"/bin/sh", "-c", task.ShellCmd},
+		Cmd:         task.Cmd,
+		Env:         task.Env,
 		StopSignal:  "SIGKILL",
 		StopTimeout: &stopTimeout,
 		User:        fmt.Sprintf("%d:%d", task.UID, task.GID),
@@ -111,8 +180,17 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 
 	containerID := resp.ID
 
+	// Containers must be removed by the caller once it is created
+	stopCtx := context.WithoutCancel(ctx)
+	started := false
+	defer func() {
+		if !started {
+			removeContainer(stopCtx, cli, containerID)
+		}
+	}()
+
 	handle := &Handle{}
-	handle.DoneCh = make(chan interface{})
+	handle.DoneCh = make(chan *Result, 1)
 
 	log.Println("stats")
 
@@ -169,15 +247,23 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 	}()
 	log.Println("start")
 
+	startedAt := time.Now()
 	if err := cli.ContainerStart(ctx, containerID, types.ContainerStartOptions{}); err != nil {
 		return nil, errors.Wrap(err, "failed to start container")
 	}
 
 	log.Println("wait")
 
+	var wallTimeExceeded atomic.Bool
+	waitDoneCh := make(chan struct{})
+
+	started = true
+
 	respCh, errCh := cli.ContainerWait(ctx, containerID, "")
 	go func() {
 		defer close(handle.DoneCh)
+		defer close(waitDoneCh)
+		defer removeContainer(stopCtx, cli, containerID)
 
 		select {
 		case <-ctx.Done():
@@ -185,26 +271,46 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 
 		case resp := <-respCh:
 			log.Printf("resp: %+v", resp)
-			handle.DoneCh <- resp
+
+			oomKilled := false
+			inspect, err := cli.ContainerInspect(stopCtx, containerID)
+			if err != nil {
+				log.Println("err(inspect): ", err)
+			} else if inspect.State != nil {
+				oomKilled = inspect.State.OOMKilled
+			}
+
+			result := &Result{
+				ExitCode: resp.StatusCode,
+				Reason:   exitReason(resp.StatusCode, wallTimeExceeded.Load(), oomKilled, time.Since(startedAt), &task.Limits),
+			}
+			switch result.Reason {
+			case ReasonMemoryExceeded:
+				result.MemoryLimit = task.Limits.Memory
+			case ReasonSignaled:
+				result.Signal, _ = signalOf(resp.StatusCode)
+			}
+			handle.DoneCh <- result
 
 		case err := <-errCh:
 			log.Printf("err: %+v", err)
-			handle.DoneCh <- err
+			handle.DoneCh <- &Result{
+				Err: err,
+			}
 		}
 	}()
 
 	// Realtime checking apart from cgroup limits to prevent sleep() function running infinite.
-	stopCtx := context.WithoutCancel(ctx)
 	go func() {
-		const extensionSec = 3
-		t := time.NewTimer(time.Duration(task.Limits.CPUTime+extensionSec) * time.Second)
+		t := time.NewTimer(task.Limits.EffectiveWallTime())
 		defer t.Stop()
 
 		select {
-		case <-handle.DoneCh:
+		case <-waitDoneCh:
 			log.Println("done")
 
 		case <-t.C:
+			wallTimeExceeded.Store(true)
 			immidiate := 0
 			err := cli.ContainerStop(stopCtx, containerID, container.StopOptions{
 				Timeout: &immidiate,
@@ -221,3 +327,53 @@ func (e *DockerRunner) Run(ctx context.Context, task *RunTask) (*Handle, error)
 
 	return handle, nil
 }
+
+// exitReason infers why the container exited.
+// The soft limit of RLIMIT_CPU sends SIGXCPU, and the hard limit, one second later in CPU time, sends SIGKILL to processes ignoring it.
+// Since Docker reports only exit codes, SIGKILL is attributed to the CPU time limit if the container has run long enough
+// to consume the hard limit on available cores. Processes killing themselves by SIGKILL after that are also reported so.
+func exitReason(exitCode int64, wallTimeExceeded, oomKilled bool, elapsed time.Duration, limits *ResourceLimits) Reason {
+	switch {
+	case wallTimeExceeded:
+		return ReasonWallTimeExceeded
+	case oomKilled:
+		return ReasonMemoryExceeded
+	case exitCode == exitCodeSIGXCPU:
+		return ReasonCPUTimeExceeded
+	case exitCode == exitCodeSIGKILL && limits.CPUTime > 0 && elapsed >= limits.minCPUHardLimitTime():
+		return ReasonCPUTimeExceeded
+	default:
+		if _, ok := signalOf(exitCode); ok {
+			return ReasonSignaled
+		}
+		return ReasonNone
+	}
+}
+
+// minCPUHardLimitTime returns the shortest wall time for a process to reach the hard limit of RLIMIT_CPU,
+// which is when all cores available to the container are used.
+func (l *ResourceLimits) minCPUHardLimitTime() time.Duration {
+	hard := time.Duration(l.CPUTime+1) * time.Second
+	return time.Duration(float64(hard) / l.cores())
+}
+
+// cores returns the number of cores available to the container, bounded by the quota and the cpuset.
+func (l *ResourceLimits) cores() float64 {
+	cores := float64(runtime.NumCPU())
+	if cpus, err := ParseCPUSet(l.CPUSet); err == nil && len(cpus) > 0 {
+		cores = math.Min(cores, float64(len(cpus)))
+	}
+	if l.CPUQuota > 0 && l.CPUPeriod > 0 {
+		cores = math.Min(cores, float64(l.CPUQuota)/float64(l.CPUPeriod))
+	}
+	return cores
+}
+
+func removeContainer(ctx context.Context, cli *client.Client, containerID string) {
+	err := cli.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{
+		Force: true,
+	})
+	if err != nil {
+		log.Println("err(remove): ", err)
+	}
+}